@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+// UserIDFromIncoming достаёт Bearer-токен из gRPC metadata ("authorization"),
+// проверяет подпись и возвращает sub (id пользователя).
+func UserIDFromIncoming(ctx context.Context, secret []byte) (string, error) {
+	claims, err := ClaimsFromIncoming(ctx, secret)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ClaimsFromIncoming — то же, но отдаёт все claims (jti, iat, exp нужны для отзыва).
+func ClaimsFromIncoming(ctx context.Context, secret []byte) (*jwt.RegisteredClaims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errors.New("no metadata")
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return nil, errors.New("no authorization")
+	}
+	token := vals[0]
+	if strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		token = token[7:]
+	}
+	return ParseToken(token, secret)
+}
+
+// ParseToken проверяет HS256-подпись access-токена и возвращает его claims.
+func ParseToken(token string, secret []byte) (*jwt.RegisteredClaims, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("unexpected signing method")
+		}
+		return secret, nil
+	})
+	if err != nil || claims.Subject == "" {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
@@ -29,12 +29,40 @@ type Config struct {
 		Password string `mapstructure:"password"`
 	} `mapstructure:"redis"`
 
+	// Revoke — список отозванных токенов (pkg/revoke)
+	Revoke struct {
+		// AllowMemory — без redis.addr держать список в памяти процесса; годится, только если
+		// токены проверяет один процесс (тесты, локальный запуск одного сервиса)
+		AllowMemory bool `mapstructure:"allow_memory"`
+		// FailOpen — пускать запрос, если хранилище отзыва недоступно (по умолчанию — отказ)
+		FailOpen bool `mapstructure:"fail_open"`
+	} `mapstructure:"revoke"`
+
 	Kafka struct {
 		Brokers []string `mapstructure:"brokers"`
 		Group   string   `mapstructure:"group"`
 		Topics  struct {
-			PostCreated string `mapstructure:"post_created"`
+			PostCreated    string `mapstructure:"post_created"`
+			PostDeleted    string `mapstructure:"post_deleted"`
+			FollowCreated  string `mapstructure:"follow_created"`
+			FollowDeleted  string `mapstructure:"follow_deleted"`
+			LikeCreated    string `mapstructure:"like_created"`
+			CommentCreated string `mapstructure:"comment_created"`
+			// FeedDLQ — куда feed consumer кладёт события (любого топика), которые не смог обработать
+			FeedDLQ string `mapstructure:"feed_dlq"`
 		} `mapstructure:"topics"`
+		// Consumer — повторы обработки сообщения до отправки в DLQ
+		Consumer struct {
+			MaxAttempts int           `mapstructure:"max_attempts"`
+			MinBackoff  time.Duration `mapstructure:"min_backoff"`
+			MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+		} `mapstructure:"consumer"`
+		// Outbox — relay событий из таблицы outbox (content)
+		Outbox struct {
+			Interval  time.Duration `mapstructure:"interval"` // пауза, когда отправлять нечего
+			BatchSize int           `mapstructure:"batch_size"`
+			Retention time.Duration `mapstructure:"retention"` // сколько хранить отправленные
+		} `mapstructure:"outbox"`
 	} `mapstructure:"kafka"`
 
 	JWT struct {
@@ -49,19 +77,72 @@ type Config struct {
 		S3         bool          `mapstructure:"s3"`
 		Bucket     string        `mapstructure:"bucket"`
 		Endpoint   string        `mapstructure:"endpoint"`
+		Region     string        `mapstructure:"region"`
 		AccessKey  string        `mapstructure:"access_key"`
 		SecretKey  string        `mapstructure:"secret_key"`
 		PresignTTL time.Duration `mapstructure:"presign_ttl"`
+		// MaxUploadSize — предел размера одного файла в байтах (0 — значение по умолчанию в content)
+		MaxUploadSize int64 `mapstructure:"max_upload_size"`
+		// Подписанные ссылки на локальные файлы: база URL (GET /media/{key} через gateway)
+		// и HMAC-ключ (по умолчанию jwt.secret)
+		PublicURL string `mapstructure:"public_url"`
+		URLSecret string `mapstructure:"url_secret"`
 	} `mapstructure:"storage"`
 
+	// Обработка картинок в content
+	Media struct {
+		// Variants — уменьшенные копии (thumb, 640, 1080 ...); пусто — не нарезаем
+		Variants    []ImageVariant `mapstructure:"variants"`
+		JPEGQuality int            `mapstructure:"jpeg_quality"`
+		MaxPixels   int64          `mapstructure:"max_pixels"` // width*height оригинала, защита от "бомб"
+		// нарезка идёт в фоне: столько картинок одновременно и столько ждут в очереди
+		// (очередь полна — медиа остаётся без вариантов)
+		VariantWorkers int `mapstructure:"variant_workers"`
+		VariantQueue   int `mapstructure:"variant_queue"`
+		// AllowedTypes — разрешённые типы (по содержимому файла) и лимит размера для каждого,
+		// 0 — только storage.max_upload_size; пусто — набор по умолчанию в content
+		AllowedTypes map[string]int64 `mapstructure:"allowed_types"`
+		// GC — сборщик медиа без ссылок (пост так и не создали, аватарку сменили, пост давно удалён)
+		GC struct {
+			Interval         time.Duration `mapstructure:"interval"`          // 0 — сборщик выключен
+			Grace            time.Duration `mapstructure:"grace"`             // сколько медиа может жить без ссылок
+			DeletedRetention time.Duration `mapstructure:"deleted_retention"` // сколько удалённый пост держит медиа
+			BatchSize        int           `mapstructure:"batch_size"`
+			DryRun           bool          `mapstructure:"dry_run"` // только лог: что было бы удалено
+		} `mapstructure:"gc"`
+	} `mapstructure:"media"`
+
 	// Эндпоинты других сервисов
 	Identity struct {
 		Endpoint string `mapstructure:"endpoint"`
 	} `mapstructure:"identity"`
 	Content struct {
-		Endpoint string `mapstructure:"endpoint"`
+		Endpoint     string `mapstructure:"endpoint"`
+		HTTPEndpoint string `mapstructure:"http_endpoint"` // HTTP content для проксирования /media/
 	} `mapstructure:"content"`
 	Feed struct {
-		Endpoint string `mapstructure:"endpoint"`
+		Endpoint     string `mapstructure:"endpoint"`
+		CursorSecret string `mapstructure:"cursor_secret"` // HMAC-ключ курсоров ленты (по умолчанию jwt.secret)
+		// PushThreshold — с какого числа подписчиков посты автора не раскладываются по лентам,
+		// а подмешиваются при чтении (0 — всегда push)
+		PushThreshold int `mapstructure:"push_threshold"`
+		// FanoutChunkSize — подписчиков на одну транзакцию фан-аута
+		FanoutChunkSize int `mapstructure:"fanout_chunk_size"`
+		// BackfillPosts — сколько последних постов автора добавить в ленту при подписке
+		BackfillPosts int `mapstructure:"backfill_posts"`
 	} `mapstructure:"feed"`
+	Social struct {
+		Endpoint string `mapstructure:"endpoint"`
+	} `mapstructure:"social"`
+	Comments struct {
+		Endpoint string `mapstructure:"endpoint"`
+	} `mapstructure:"comments"`
+}
+
+// ImageVariant — один размер: Square — центральный квадрат Width×Width,
+// иначе ширина Width с сохранением пропорций
+type ImageVariant struct {
+	Name   string `mapstructure:"name"`
+	Width  int    `mapstructure:"width"`
+	Square bool   `mapstructure:"square"`
 }
@@ -0,0 +1,172 @@
+// Package events — кодирование событий Kafka (proto/events) и выбор типа по заголовку schema.
+// Producers и consumers ходят только через него, чтобы форматы не расходились.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	evpb "github.com/mariapetrova3009/insta-backend/proto/events"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// заголовки сообщения
+const (
+	HeaderSchema      = "schema"
+	HeaderContentType = "content-type"
+)
+
+const (
+	ContentTypeProto = "application/x-protobuf"
+	ContentTypeJSON  = "application/json"
+)
+
+// schema = <сервис>.<сущность>.<событие>.v<N>; новая версия — новая строка, старые декодируются дальше
+const (
+	// PostCreatedV1 — старый JSON из content (только чтение)
+	PostCreatedV1    = "content.post.created.v1"
+	PostCreatedV2    = "content.post.created.v2"
+	PostDeletedV1    = "content.post.deleted.v1"
+	FollowCreatedV1  = "social.follow.created.v1"
+	FollowDeletedV1  = "social.follow.deleted.v1"
+	LikeCreatedV1    = "social.like.created.v1"
+	CommentCreatedV1 = "comments.comment.created.v1"
+)
+
+var (
+	ErrUnknownSchema = errors.New("unknown event schema")
+	ErrUnknownType   = errors.New("event type has no schema")
+)
+
+type decoder func(payload []byte) (proto.Message, error)
+
+// decoders — все версии, которые умеем читать
+var decoders = map[string]decoder{
+	PostCreatedV1:    decodePostCreatedJSON,
+	PostCreatedV2:    protoDecoder[evpb.PostCreated](),
+	PostDeletedV1:    protoDecoder[evpb.PostDeleted](),
+	FollowCreatedV1:  protoDecoder[evpb.FollowCreated](),
+	FollowDeletedV1:  protoDecoder[evpb.FollowDeleted](),
+	LikeCreatedV1:    protoDecoder[evpb.LikeCreated](),
+	CommentCreatedV1: protoDecoder[evpb.CommentCreated](),
+}
+
+// current — версия, которой пишем каждый тип
+var current = map[protoreflect.FullName]string{
+	(*evpb.PostCreated)(nil).ProtoReflect().Descriptor().FullName():    PostCreatedV2,
+	(*evpb.PostDeleted)(nil).ProtoReflect().Descriptor().FullName():    PostDeletedV1,
+	(*evpb.FollowCreated)(nil).ProtoReflect().Descriptor().FullName():  FollowCreatedV1,
+	(*evpb.FollowDeleted)(nil).ProtoReflect().Descriptor().FullName():  FollowDeletedV1,
+	(*evpb.LikeCreated)(nil).ProtoReflect().Descriptor().FullName():    LikeCreatedV1,
+	(*evpb.CommentCreated)(nil).ProtoReflect().Descriptor().FullName(): CommentCreatedV1,
+}
+
+// Encoded — готовое к отправке событие
+type Encoded struct {
+	Schema      string
+	ContentType string
+	Payload     []byte
+}
+
+// Encode сериализует событие текущей версией его schema
+func Encode(msg proto.Message) (Encoded, error) {
+	name := msg.ProtoReflect().Descriptor().FullName()
+	schema, ok := current[name]
+	if !ok {
+		return Encoded{}, fmt.Errorf("%w: %s", ErrUnknownType, name)
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return Encoded{}, err
+	}
+	return Encoded{Schema: schema, ContentType: ContentTypeProto, Payload: payload}, nil
+}
+
+// Headers — заголовки для Kafka
+func (e Encoded) Headers() []kafka.Header {
+	return []kafka.Header{
+		{Key: HeaderSchema, Value: []byte(e.Schema)},
+		{Key: HeaderContentType, Value: []byte(e.ContentType)},
+	}
+}
+
+// Produce кодирует событие и отдаёт его producer'у (fire-and-forget: ошибки доставки
+// приходят в p.Events(), их логирует main). nil producer или пустой topic — события выключены.
+func Produce(p *kafka.Producer, topic, key string, msg proto.Message) error {
+	if p == nil || topic == "" {
+		return nil
+	}
+	evt, err := Encode(msg)
+	if err != nil {
+		return err
+	}
+	return p.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            []byte(key),
+		Value:          evt.Payload,
+		Headers:        evt.Headers(),
+	}, nil)
+}
+
+// Decode разбирает payload по schema; тип результата — *evpb.PostCreated и т.д.
+func Decode(schema string, payload []byte) (proto.Message, error) {
+	dec, ok := decoders[schema]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSchema, schema)
+	}
+	msg, err := dec(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", schema, err)
+	}
+	return msg, nil
+}
+
+// DecodeMessage — Decode по заголовку schema сообщения Kafka
+func DecodeMessage(m *kafka.Message) (proto.Message, error) {
+	return Decode(Schema(m.Headers), m.Value)
+}
+
+// Schema — значение заголовка schema ("" если его нет)
+func Schema(headers []kafka.Header) string {
+	for _, h := range headers {
+		if h.Key == HeaderSchema {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func protoDecoder[T any, P interface {
+	*T
+	proto.Message
+}]() decoder {
+	return func(payload []byte) (proto.Message, error) {
+		var msg P = new(T)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+}
+
+// decodePostCreatedJSON — формат content до перехода на protobuf
+func decodePostCreatedJSON(payload []byte) (proto.Message, error) {
+	var v struct {
+		PostID      string `json:"post_id"`
+		AuthorID    string `json:"author_id"`
+		CreatedAtMs int64  `json:"created_at_ms"`
+	}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return nil, err
+	}
+	return &evpb.PostCreated{
+		PostId:    v.PostID,
+		AuthorId:  v.AuthorID,
+		CreatedAt: timestamppb.New(time.UnixMilli(v.CreatedAtMs)),
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Package mediaref — ссылки на медиа content (media.ref_count) из любого сервиса общей БД.
+//
+// Ссылку на медиа держат посты (content) и аватарки (identity). Правило одно для всех:
+// ссылка появляется — +1, пропадает — -1 (не ниже нуля), в той же транзакции, что и сама
+// ссылка. Файлы сервисы сами не удаляют: медиа с ref_count = 0 после grace убирает
+// сборщик content (services/content/internal/gc).
+package mediaref
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Tx — *sql.Tx (или *sql.DB, если ссылка меняется одним запросом)
+type Tx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Querier — *sql.DB или *sql.Tx
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// UploadedBy — mime медиа по пути, если его загружал userID (content пишет media_uploads
+// при каждой загрузке, в том числе совпавшей с чужой по содержимому); "" — нет такого
+// медиа или оно чужое. Ссылаться можно только на своё медиа.
+func UploadedBy(ctx context.Context, q Querier, path, userID string) (mime string, err error) {
+	err = q.QueryRowContext(ctx,
+		`SELECT m.mime FROM media m JOIN media_uploads u ON u.media_id = m.id
+	WHERE m.path = $1 AND u.user_id = $2`, path, userID).Scan(&mime)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return mime, err
+}
+
+// Acquire добавляет ссылку на медиа id; false — такого медиа нет
+func Acquire(ctx context.Context, tx Tx, id uuid.UUID) (bool, error) {
+	return exec(ctx, tx, `UPDATE media SET ref_count = ref_count + 1 WHERE id = $1`, id)
+}
+
+// AcquirePath — Acquire по пути файла в хранилище
+func AcquirePath(ctx context.Context, tx Tx, path string) (bool, error) {
+	return exec(ctx, tx, `UPDATE media SET ref_count = ref_count + 1 WHERE path = $1`, path)
+}
+
+// ReleasePath снимает ссылку с медиа по пути; медиа, которого уже нет, не ошибка
+func ReleasePath(ctx context.Context, tx Tx, path string) error {
+	_, err := exec(ctx, tx, `UPDATE media SET ref_count = GREATEST(ref_count - 1, 0) WHERE path = $1`, path)
+	return err
+}
+
+func exec(ctx context.Context, tx Tx, query string, arg any) (bool, error) {
+	res, err := tx.ExecContext(ctx, query, arg)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
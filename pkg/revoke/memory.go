@@ -0,0 +1,84 @@
+package revoke
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory — in-memory список; годится для одного процесса
+// и как локальный кэш перед Redis.
+type Memory struct {
+	mu     sync.Mutex
+	tokens map[string]time.Time // jti -> exp
+	users  map[string]userCut   // user_id -> отметка
+	maxTTL time.Duration
+	lastGC time.Time
+}
+
+type userCut struct {
+	before time.Time
+	until  time.Time // после этого все затронутые токены уже истекли
+}
+
+// NewMemory: maxTTL — максимальное время жизни access-токена
+func NewMemory(maxTTL time.Duration) *Memory {
+	return &Memory{
+		tokens: make(map[string]time.Time),
+		users:  make(map[string]userCut),
+		maxTTL: maxTTL,
+	}
+}
+
+func (m *Memory) RevokeToken(_ context.Context, jti string, exp time.Time) error {
+	if jti == "" {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[jti] = exp
+	m.gcLocked()
+	return nil
+}
+
+func (m *Memory) RevokeUser(_ context.Context, userID string, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur, ok := m.users[userID]; ok && cur.before.After(before) {
+		return nil
+	}
+	m.users[userID] = userCut{before: before, until: before.Add(m.maxTTL)}
+	m.gcLocked()
+	return nil
+}
+
+func (m *Memory) IsRevoked(_ context.Context, userID, jti string, issuedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if exp, ok := m.tokens[jti]; ok && time.Now().Before(exp) {
+		return true, nil
+	}
+	if cut, ok := m.users[userID]; ok && !issuedAt.After(cut.before) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// gcLocked раз в минуту выкидывает записи, которые уже ни на что не влияют
+func (m *Memory) gcLocked() {
+	now := time.Now()
+	if now.Sub(m.lastGC) < time.Minute {
+		return
+	}
+	m.lastGC = now
+	for jti, exp := range m.tokens {
+		if now.After(exp) {
+			delete(m.tokens, jti)
+		}
+	}
+	for uid, cut := range m.users {
+		if now.After(cut.until) {
+			delete(m.users, uid)
+		}
+	}
+}
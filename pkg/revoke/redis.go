@@ -0,0 +1,72 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis — общий для всех сервисов список в Redis с локальным Memory-кэшем:
+// уже известные отзывы проверяются без похода в сеть.
+type Redis struct {
+	rdb    *redis.Client
+	local  *Memory
+	maxTTL time.Duration
+}
+
+func NewRedis(rdb *redis.Client, maxTTL time.Duration) *Redis {
+	return &Redis{rdb: rdb, local: NewMemory(maxTTL), maxTTL: maxTTL}
+}
+
+func jtiKey(jti string) string     { return "revoke:jti:" + jti }
+func userKey(userID string) string { return "revoke:user:" + userID }
+
+func (r *Redis) RevokeToken(ctx context.Context, jti string, exp time.Time) error {
+	if jti == "" {
+		return nil
+	}
+	_ = r.local.RevokeToken(ctx, jti, exp)
+	ttl := time.Until(exp)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.rdb.Set(ctx, jtiKey(jti), 1, ttl).Err()
+}
+
+func (r *Redis) RevokeUser(ctx context.Context, userID string, before time.Time) error {
+	_ = r.local.RevokeUser(ctx, userID, before)
+	return r.rdb.Set(ctx, userKey(userID), before.Unix(), r.maxTTL).Err()
+}
+
+// IsRevoked при недоступности Redis возвращает ответ локального кэша вместе с ошибкой —
+// вызывающий решает, пускать ли запрос.
+func (r *Redis) IsRevoked(ctx context.Context, userID, jti string, issuedAt time.Time) (bool, error) {
+	if ok, _ := r.local.IsRevoked(ctx, userID, jti, issuedAt); ok {
+		return true, nil
+	}
+
+	vals, err := r.rdb.MGet(ctx, jtiKey(jti), userKey(userID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+	if len(vals) == 2 {
+		if vals[0] != nil {
+			// точный exp не знаем — держим в кэше максимум жизни токена
+			_ = r.local.RevokeToken(ctx, jti, issuedAt.Add(r.maxTTL))
+			return true, nil
+		}
+		if s, ok := vals[1].(string); ok {
+			if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+				before := time.Unix(sec, 0)
+				_ = r.local.RevokeUser(ctx, userID, before)
+				if !issuedAt.After(before) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
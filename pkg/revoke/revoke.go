@@ -0,0 +1,59 @@
+// Package revoke — список отозванных access-токенов (logout / logout everywhere).
+//
+// Токен считается отозванным, если отозван его jti или если он выпущен
+// не позже отметки "отозвать всё до" для пользователя. Записи живут не дольше
+// самих токенов, поэтому список не растёт бесконечно.
+package revoke
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+type Store interface {
+	// RevokeToken отзывает один токен до момента его истечения
+	RevokeToken(ctx context.Context, jti string, exp time.Time) error
+	// RevokeUser отзывает все токены пользователя, выпущенные не позже before
+	RevokeUser(ctx context.Context, userID string, before time.Time) error
+	// IsRevoked проверяет токен по jti, sub и iat
+	IsRevoked(ctx context.Context, userID, jti string, issuedAt time.Time) (bool, error)
+}
+
+// IsClaimsRevoked — проверка распарсенного access-токена
+func IsClaimsRevoked(ctx context.Context, s Store, c *jwt.RegisteredClaims) (bool, error) {
+	var iat time.Time
+	if c.IssuedAt != nil {
+		iat = c.IssuedAt.Time
+	}
+	return s.IsRevoked(ctx, c.Subject, c.ID, iat)
+}
+
+// ErrNoSharedStore — redis.addr не задан: отзыв из identity не увидел бы gateway
+var ErrNoSharedStore = errors.New("revoke: redis.addr is required (tokens are checked by several processes); set revoke.allow_memory for a single process")
+
+// FromConfig: Redis по redis.addr. In-memory (только в пределах процесса) — лишь при
+// явном revoke.allow_memory, иначе ErrNoSharedStore.
+// maxTTL — время жизни access-токена (jwt.ttl).
+func FromConfig(cfg *cfgpkg.Config) (Store, error) {
+	maxTTL := cfg.JWT.TTL
+	if maxTTL <= 0 {
+		maxTTL = 24 * time.Hour
+	}
+	if cfg.Redis.Addr == "" {
+		if !cfg.Revoke.AllowMemory {
+			return nil, ErrNoSharedStore
+		}
+		return NewMemory(maxTTL), nil
+	}
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		DB:       cfg.Redis.DB,
+		Password: cfg.Redis.Password,
+	})
+	return NewRedis(rdb, maxTTL), nil
+}
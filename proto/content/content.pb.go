@@ -84,6 +84,142 @@ func (x *UploadMediaRequest) GetMime() string {
 	return ""
 }
 
+// First message carries header, the rest carry chunk
+type UploadMediaChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*UploadMediaChunk_Header
+	//	*UploadMediaChunk_Chunk
+	Payload isUploadMediaChunk_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *UploadMediaChunk) Reset() {
+	*x = UploadMediaChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_content_content_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadMediaChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMediaChunk) ProtoMessage() {}
+
+func (x *UploadMediaChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_content_content_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMediaChunk.ProtoReflect.Descriptor instead.
+func (*UploadMediaChunk) Descriptor() ([]byte, []int) {
+	return file_content_content_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *UploadMediaChunk) GetPayload() isUploadMediaChunk_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *UploadMediaChunk) GetHeader() *UploadMediaHeader {
+	if x, ok := x.GetPayload().(*UploadMediaChunk_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UploadMediaChunk) GetChunk() []byte {
+	if x, ok := x.GetPayload().(*UploadMediaChunk_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isUploadMediaChunk_Payload interface {
+	isUploadMediaChunk_Payload()
+}
+
+type UploadMediaChunk_Header struct {
+	Header *UploadMediaHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type UploadMediaChunk_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadMediaChunk_Header) isUploadMediaChunk_Payload() {}
+
+func (*UploadMediaChunk_Chunk) isUploadMediaChunk_Payload() {}
+
+type UploadMediaHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mime string `protobuf:"bytes,2,opt,name=mime,proto3" json:"mime,omitempty"`
+}
+
+func (x *UploadMediaHeader) Reset() {
+	*x = UploadMediaHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_content_content_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadMediaHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMediaHeader) ProtoMessage() {}
+
+func (x *UploadMediaHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_content_content_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMediaHeader.ProtoReflect.Descriptor instead.
+func (*UploadMediaHeader) Descriptor() ([]byte, []int) {
+	return file_content_content_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadMediaHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadMediaHeader) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
 type UploadMediaResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -97,7 +233,7 @@ type UploadMediaResponse struct {
 func (x *UploadMediaResponse) Reset() {
 	*x = UploadMediaResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_content_content_proto_msgTypes[1]
+		mi := &file_content_content_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -110,7 +246,7 @@ func (x *UploadMediaResponse) String() string {
 func (*UploadMediaResponse) ProtoMessage() {}
 
 func (x *UploadMediaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_content_content_proto_msgTypes[1]
+	mi := &file_content_content_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,7 +259,7 @@ func (x *UploadMediaResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMediaResponse.ProtoReflect.Descriptor instead.
 func (*UploadMediaResponse) Descriptor() ([]byte, []int) {
-	return file_content_content_proto_rawDescGZIP(), []int{1}
+	return file_content_content_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UploadMediaResponse) GetMediaPath() string {
@@ -160,7 +296,7 @@ type CreatePostRequest struct {
 func (x *CreatePostRequest) Reset() {
 	*x = CreatePostRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_content_content_proto_msgTypes[2]
+		mi := &file_content_content_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -173,7 +309,7 @@ func (x *CreatePostRequest) String() string {
 func (*CreatePostRequest) ProtoMessage() {}
 
 func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_content_content_proto_msgTypes[2]
+	mi := &file_content_content_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -186,7 +322,7 @@ func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
 func (*CreatePostRequest) Descriptor() ([]byte, []int) {
-	return file_content_content_proto_rawDescGZIP(), []int{2}
+	return file_content_content_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreatePostRequest) GetCaption() string {
@@ -221,7 +357,7 @@ type GetPostRequest struct {
 func (x *GetPostRequest) Reset() {
 	*x = GetPostRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_content_content_proto_msgTypes[3]
+		mi := &file_content_content_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -234,7 +370,7 @@ func (x *GetPostRequest) String() string {
 func (*GetPostRequest) ProtoMessage() {}
 
 func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_content_content_proto_msgTypes[3]
+	mi := &file_content_content_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +383,7 @@ func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
 func (*GetPostRequest) Descriptor() ([]byte, []int) {
-	return file_content_content_proto_rawDescGZIP(), []int{3}
+	return file_content_content_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetPostRequest) GetPostId() string {
@@ -257,6 +393,53 @@ func (x *GetPostRequest) GetPostId() string {
 	return ""
 }
 
+type DeletePostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostId string `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+}
+
+func (x *DeletePostRequest) Reset() {
+	*x = DeletePostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_content_content_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostRequest) ProtoMessage() {}
+
+func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_content_content_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostRequest) Descriptor() ([]byte, []int) {
+	return file_content_content_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeletePostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
 type PostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -268,7 +451,7 @@ type PostResponse struct {
 func (x *PostResponse) Reset() {
 	*x = PostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_content_content_proto_msgTypes[4]
+		mi := &file_content_content_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -281,7 +464,7 @@ func (x *PostResponse) String() string {
 func (*PostResponse) ProtoMessage() {}
 
 func (x *PostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_content_content_proto_msgTypes[4]
+	mi := &file_content_content_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -294,7 +477,7 @@ func (x *PostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostResponse.ProtoReflect.Descriptor instead.
 func (*PostResponse) Descriptor() ([]byte, []int) {
-	return file_content_content_proto_rawDescGZIP(), []int{4}
+	return file_content_content_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PostResponse) GetPost() *common.Post {
@@ -304,6 +487,100 @@ func (x *PostResponse) GetPost() *common.Post {
 	return nil
 }
 
+type BatchGetPostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostIds []string `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"`
+}
+
+func (x *BatchGetPostsRequest) Reset() {
+	*x = BatchGetPostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_content_content_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGetPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPostsRequest) ProtoMessage() {}
+
+func (x *BatchGetPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_content_content_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPostsRequest) Descriptor() ([]byte, []int) {
+	return file_content_content_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchGetPostsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type BatchGetPostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Posts []*common.Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+}
+
+func (x *BatchGetPostsResponse) Reset() {
+	*x = BatchGetPostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_content_content_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGetPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPostsResponse) ProtoMessage() {}
+
+func (x *BatchGetPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_content_content_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPostsResponse) Descriptor() ([]byte, []int) {
+	return file_content_content_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetPostsResponse) GetPosts() []*common.Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
 var File_content_content_proto protoreflect.FileDescriptor
 
 var file_content_content_proto_rawDesc = []byte{
@@ -315,6 +592,17 @@ var file_content_content_proto_rawDesc = []byte{
 	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12,
 	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
 	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x22, 0x71, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x3a, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3b, 0x0a, 0x11, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x22, 0x5c, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
 	0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a,
 	0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
@@ -330,17 +618,33 @@ var file_content_content_proto_rawDesc = []byte{
 	0x52, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73,
 	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49,
-	0x64, 0x22, 0x36, 0x0a, 0x0c, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x26, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50,
-	0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x32, 0xfa, 0x01, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x0b,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x21, 0x2e, 0x69, 0x6e,
+	0x64, 0x22, 0x2c, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x22,
+	0x36, 0x0a, 0x0c, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x26, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x73, 0x22, 0x41, 0x0a, 0x15, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x32, 0xf7, 0x03,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x54, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12,
+	0x21, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1f, 0x2e, 0x69, 0x6e,
 	0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
-	0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
+	0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x22, 0x2e, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x12, 0x4b, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
 	0x12, 0x20, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
 	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65,
@@ -349,12 +653,22 @@ var file_content_content_proto_rawDesc = []byte{
 	0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f,
 	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6e, 0x73, 0x74,
 	0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x72, 0x69, 0x61, 0x70, 0x65, 0x74, 0x72, 0x6f, 0x76,
-	0x61, 0x33, 0x30, 0x30, 0x39, 0x2f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2d, 0x62, 0x61, 0x63, 0x6b,
-	0x65, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x3b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47,
+	0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
+	0x12, 0x20, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x72, 0x69, 0x61, 0x70, 0x65, 0x74, 0x72, 0x6f,
+	0x76, 0x61, 0x33, 0x30, 0x30, 0x39, 0x2f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x2d, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x3b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -369,28 +683,42 @@ func file_content_content_proto_rawDescGZIP() []byte {
 	return file_content_content_proto_rawDescData
 }
 
-var file_content_content_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_content_content_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_content_content_proto_goTypes = []interface{}{
-	(*UploadMediaRequest)(nil),  // 0: insta.content.UploadMediaRequest
-	(*UploadMediaResponse)(nil), // 1: insta.content.UploadMediaResponse
-	(*CreatePostRequest)(nil),   // 2: insta.content.CreatePostRequest
-	(*GetPostRequest)(nil),      // 3: insta.content.GetPostRequest
-	(*PostResponse)(nil),        // 4: insta.content.PostResponse
-	(*common.Post)(nil),         // 5: insta.common.Post
+	(*UploadMediaRequest)(nil),    // 0: insta.content.UploadMediaRequest
+	(*UploadMediaChunk)(nil),      // 1: insta.content.UploadMediaChunk
+	(*UploadMediaHeader)(nil),     // 2: insta.content.UploadMediaHeader
+	(*UploadMediaResponse)(nil),   // 3: insta.content.UploadMediaResponse
+	(*CreatePostRequest)(nil),     // 4: insta.content.CreatePostRequest
+	(*GetPostRequest)(nil),        // 5: insta.content.GetPostRequest
+	(*DeletePostRequest)(nil),     // 6: insta.content.DeletePostRequest
+	(*PostResponse)(nil),          // 7: insta.content.PostResponse
+	(*BatchGetPostsRequest)(nil),  // 8: insta.content.BatchGetPostsRequest
+	(*BatchGetPostsResponse)(nil), // 9: insta.content.BatchGetPostsResponse
+	(*common.Post)(nil),           // 10: insta.common.Post
+	(*common.Empty)(nil),          // 11: insta.common.Empty
 }
 var file_content_content_proto_depIdxs = []int32{
-	5, // 0: insta.content.PostResponse.post:type_name -> insta.common.Post
-	0, // 1: insta.content.ContentService.UploadMedia:input_type -> insta.content.UploadMediaRequest
-	2, // 2: insta.content.ContentService.CreatePost:input_type -> insta.content.CreatePostRequest
-	3, // 3: insta.content.ContentService.GetPost:input_type -> insta.content.GetPostRequest
-	1, // 4: insta.content.ContentService.UploadMedia:output_type -> insta.content.UploadMediaResponse
-	4, // 5: insta.content.ContentService.CreatePost:output_type -> insta.content.PostResponse
-	4, // 6: insta.content.ContentService.GetPost:output_type -> insta.content.PostResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2,  // 0: insta.content.UploadMediaChunk.header:type_name -> insta.content.UploadMediaHeader
+	10, // 1: insta.content.PostResponse.post:type_name -> insta.common.Post
+	10, // 2: insta.content.BatchGetPostsResponse.posts:type_name -> insta.common.Post
+	0,  // 3: insta.content.ContentService.UploadMedia:input_type -> insta.content.UploadMediaRequest
+	1,  // 4: insta.content.ContentService.UploadMediaStream:input_type -> insta.content.UploadMediaChunk
+	4,  // 5: insta.content.ContentService.CreatePost:input_type -> insta.content.CreatePostRequest
+	5,  // 6: insta.content.ContentService.GetPost:input_type -> insta.content.GetPostRequest
+	8,  // 7: insta.content.ContentService.BatchGetPosts:input_type -> insta.content.BatchGetPostsRequest
+	6,  // 8: insta.content.ContentService.DeletePost:input_type -> insta.content.DeletePostRequest
+	3,  // 9: insta.content.ContentService.UploadMedia:output_type -> insta.content.UploadMediaResponse
+	3,  // 10: insta.content.ContentService.UploadMediaStream:output_type -> insta.content.UploadMediaResponse
+	7,  // 11: insta.content.ContentService.CreatePost:output_type -> insta.content.PostResponse
+	7,  // 12: insta.content.ContentService.GetPost:output_type -> insta.content.PostResponse
+	9,  // 13: insta.content.ContentService.BatchGetPosts:output_type -> insta.content.BatchGetPostsResponse
+	11, // 14: insta.content.ContentService.DeletePost:output_type -> insta.common.Empty
+	9,  // [9:15] is the sub-list for method output_type
+	3,  // [3:9] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_content_content_proto_init() }
@@ -412,7 +740,7 @@ func file_content_content_proto_init() {
 			}
 		}
 		file_content_content_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadMediaResponse); i {
+			switch v := v.(*UploadMediaChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -424,7 +752,7 @@ func file_content_content_proto_init() {
 			}
 		}
 		file_content_content_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreatePostRequest); i {
+			switch v := v.(*UploadMediaHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -436,7 +764,7 @@ func file_content_content_proto_init() {
 			}
 		}
 		file_content_content_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPostRequest); i {
+			switch v := v.(*UploadMediaResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -448,6 +776,42 @@ func file_content_content_proto_init() {
 			}
 		}
 		file_content_content_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreatePostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_content_content_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_content_content_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_content_content_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PostResponse); i {
 			case 0:
 				return &v.state
@@ -459,6 +823,34 @@ func file_content_content_proto_init() {
 				return nil
 			}
 		}
+		file_content_content_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetPostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_content_content_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetPostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_content_content_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*UploadMediaChunk_Header)(nil),
+		(*UploadMediaChunk_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -466,7 +858,7 @@ func file_content_content_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_content_content_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,6 +8,7 @@ package content
 
 import (
 	context "context"
+	common "github.com/mariapetrova3009/insta-backend/proto/common"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,9 +20,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ContentService_UploadMedia_FullMethodName = "/insta.content.ContentService/UploadMedia"
-	ContentService_CreatePost_FullMethodName  = "/insta.content.ContentService/CreatePost"
-	ContentService_GetPost_FullMethodName     = "/insta.content.ContentService/GetPost"
+	ContentService_UploadMedia_FullMethodName       = "/insta.content.ContentService/UploadMedia"
+	ContentService_UploadMediaStream_FullMethodName = "/insta.content.ContentService/UploadMediaStream"
+	ContentService_CreatePost_FullMethodName        = "/insta.content.ContentService/CreatePost"
+	ContentService_GetPost_FullMethodName           = "/insta.content.ContentService/GetPost"
+	ContentService_BatchGetPosts_FullMethodName     = "/insta.content.ContentService/BatchGetPosts"
+	ContentService_DeletePost_FullMethodName        = "/insta.content.ContentService/DeletePost"
 )
 
 // ContentServiceClient is the client API for ContentService service.
@@ -30,10 +34,16 @@ const (
 type ContentServiceClient interface {
 	// Downloading file
 	UploadMedia(ctx context.Context, in *UploadMediaRequest, opts ...grpc.CallOption) (*UploadMediaResponse, error)
+	// Streaming upload for large files: header first, then chunks
+	UploadMediaStream(ctx context.Context, opts ...grpc.CallOption) (ContentService_UploadMediaStreamClient, error)
 	// Create a post
 	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*PostResponse, error)
 	// Get a post
 	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*PostResponse, error)
+	// Get many posts at once (feed hydration); missing ids are skipped
+	BatchGetPosts(ctx context.Context, in *BatchGetPostsRequest, opts ...grpc.CallOption) (*BatchGetPostsResponse, error)
+	// Delete own post (soft delete); feeds are cleaned up via PostDeleted
+	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*common.Empty, error)
 }
 
 type contentServiceClient struct {
@@ -53,6 +63,40 @@ func (c *contentServiceClient) UploadMedia(ctx context.Context, in *UploadMediaR
 	return out, nil
 }
 
+func (c *contentServiceClient) UploadMediaStream(ctx context.Context, opts ...grpc.CallOption) (ContentService_UploadMediaStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ContentService_ServiceDesc.Streams[0], ContentService_UploadMediaStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &contentServiceUploadMediaStreamClient{stream}
+	return x, nil
+}
+
+type ContentService_UploadMediaStreamClient interface {
+	Send(*UploadMediaChunk) error
+	CloseAndRecv() (*UploadMediaResponse, error)
+	grpc.ClientStream
+}
+
+type contentServiceUploadMediaStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *contentServiceUploadMediaStreamClient) Send(m *UploadMediaChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *contentServiceUploadMediaStreamClient) CloseAndRecv() (*UploadMediaResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadMediaResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *contentServiceClient) CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*PostResponse, error) {
 	out := new(PostResponse)
 	err := c.cc.Invoke(ctx, ContentService_CreatePost_FullMethodName, in, out, opts...)
@@ -71,16 +115,40 @@ func (c *contentServiceClient) GetPost(ctx context.Context, in *GetPostRequest,
 	return out, nil
 }
 
+func (c *contentServiceClient) BatchGetPosts(ctx context.Context, in *BatchGetPostsRequest, opts ...grpc.CallOption) (*BatchGetPostsResponse, error) {
+	out := new(BatchGetPostsResponse)
+	err := c.cc.Invoke(ctx, ContentService_BatchGetPosts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentServiceClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*common.Empty, error) {
+	out := new(common.Empty)
+	err := c.cc.Invoke(ctx, ContentService_DeletePost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContentServiceServer is the server API for ContentService service.
 // All implementations must embed UnimplementedContentServiceServer
 // for forward compatibility
 type ContentServiceServer interface {
 	// Downloading file
 	UploadMedia(context.Context, *UploadMediaRequest) (*UploadMediaResponse, error)
+	// Streaming upload for large files: header first, then chunks
+	UploadMediaStream(ContentService_UploadMediaStreamServer) error
 	// Create a post
 	CreatePost(context.Context, *CreatePostRequest) (*PostResponse, error)
 	// Get a post
 	GetPost(context.Context, *GetPostRequest) (*PostResponse, error)
+	// Get many posts at once (feed hydration); missing ids are skipped
+	BatchGetPosts(context.Context, *BatchGetPostsRequest) (*BatchGetPostsResponse, error)
+	// Delete own post (soft delete); feeds are cleaned up via PostDeleted
+	DeletePost(context.Context, *DeletePostRequest) (*common.Empty, error)
 	mustEmbedUnimplementedContentServiceServer()
 }
 
@@ -91,12 +159,21 @@ type UnimplementedContentServiceServer struct {
 func (UnimplementedContentServiceServer) UploadMedia(context.Context, *UploadMediaRequest) (*UploadMediaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadMedia not implemented")
 }
+func (UnimplementedContentServiceServer) UploadMediaStream(ContentService_UploadMediaStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadMediaStream not implemented")
+}
 func (UnimplementedContentServiceServer) CreatePost(context.Context, *CreatePostRequest) (*PostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
 }
 func (UnimplementedContentServiceServer) GetPost(context.Context, *GetPostRequest) (*PostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
 }
+func (UnimplementedContentServiceServer) BatchGetPosts(context.Context, *BatchGetPostsRequest) (*BatchGetPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetPosts not implemented")
+}
+func (UnimplementedContentServiceServer) DeletePost(context.Context, *DeletePostRequest) (*common.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+}
 func (UnimplementedContentServiceServer) mustEmbedUnimplementedContentServiceServer() {}
 
 // UnsafeContentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -128,6 +205,32 @@ func _ContentService_UploadMedia_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContentService_UploadMediaStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ContentServiceServer).UploadMediaStream(&contentServiceUploadMediaStreamServer{stream})
+}
+
+type ContentService_UploadMediaStreamServer interface {
+	SendAndClose(*UploadMediaResponse) error
+	Recv() (*UploadMediaChunk, error)
+	grpc.ServerStream
+}
+
+type contentServiceUploadMediaStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *contentServiceUploadMediaStreamServer) SendAndClose(m *UploadMediaResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *contentServiceUploadMediaStreamServer) Recv() (*UploadMediaChunk, error) {
+	m := new(UploadMediaChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _ContentService_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreatePostRequest)
 	if err := dec(in); err != nil {
@@ -164,6 +267,42 @@ func _ContentService_GetPost_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContentService_BatchGetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServiceServer).BatchGetPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContentService_BatchGetPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServiceServer).BatchGetPosts(ctx, req.(*BatchGetPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContentService_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServiceServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContentService_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServiceServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ContentService_ServiceDesc is the grpc.ServiceDesc for ContentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -183,7 +322,21 @@ var ContentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPost",
 			Handler:    _ContentService_GetPost_Handler,
 		},
+		{
+			MethodName: "BatchGetPosts",
+			Handler:    _ContentService_BatchGetPosts_Handler,
+		},
+		{
+			MethodName: "DeletePost",
+			Handler:    _ContentService_DeletePost_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadMediaStream",
+			Handler:       _ContentService_UploadMediaStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "content/content.proto",
 }
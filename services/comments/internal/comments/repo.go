@@ -0,0 +1,92 @@
+package comments
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func NewRepo(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+type DBComment struct {
+	ID        string
+	PostID    string
+	UserID    string
+	Text      string
+	CreatedAt time.Time
+}
+
+// PostExists проверяет, что пост существует (таблица posts у content)
+func (r *Repo) PostExists(ctx context.Context, id string) (bool, error) {
+	var ok bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&ok)
+	return ok, err
+}
+
+// CreateComment пишет комментарий и увеличивает posts.comments_count в одной транзакции
+func (r *Repo) CreateComment(ctx context.Context, c DBComment) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO comments (id, post_id, user_id, text, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, c.ID, c.PostID, c.UserID, c.Text, c.CreatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE posts SET comments_count = comments_count + 1 WHERE id = $1
+	`, c.PostID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListComments отдаёт комментарии поста от новых к старым.
+// after == nil — первая страница, иначе всё строго "старше" (created_at, id) курсора.
+func (r *Repo) ListComments(ctx context.Context, postID string, limit uint32, after *Position) ([]DBComment, error) {
+	q := `SELECT id, post_id, user_id, text, created_at
+	FROM comments
+	WHERE post_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT $2`
+	args := []any{postID, limit}
+	if after != nil {
+		q = `SELECT id, post_id, user_id, text, created_at
+		FROM comments
+		WHERE post_id = $1 AND (created_at, id) < ($3, $4)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]DBComment, 0, limit)
+	for rows.Next() {
+		var c DBComment
+		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.Text, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,210 @@
+package comments
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/google/uuid"
+	authpkg "github.com/mariapetrova3009/insta-backend/pkg/auth"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/events"
+	cmtpb "github.com/mariapetrova3009/insta-backend/proto/comments"
+	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
+	evpb "github.com/mariapetrova3009/insta-backend/proto/events"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// максимальная длина комментария (в символах)
+const maxCommentLen = 2200
+
+// gRPC server realisation
+type Server struct {
+	cmtpb.UnimplementedCommentServiceServer
+	log       *slog.Logger
+	jwtSecret []byte
+	repo      *Repo
+
+	prod                *kafka.Producer
+	topicCommentCreated string
+}
+
+func New(log *slog.Logger, cfg *cfgpkg.Config, repo *Repo, prod *kafka.Producer) *Server {
+	return &Server{
+		log:                 log,
+		jwtSecret:           []byte(cfg.JWT.Secret),
+		repo:                repo,
+		prod:                prod,
+		topicCommentCreated: cfg.Kafka.Topics.CommentCreated,
+	}
+}
+
+// автор — из JWT
+// валидация текста и поста
+// комментарий + comments_count в одной транзакции, событие CommentCreated
+func (s *Server) CreateComment(ctx context.Context, req *cmtpb.CreateCommentRequest) (*cmtpb.CreateCommentResponse, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid post_id")
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, status.Error(codes.InvalidArgument, "text is required")
+	}
+	if utf8.RuneCountInString(text) > maxCommentLen {
+		return nil, status.Errorf(codes.InvalidArgument, "text too long (max %d)", maxCommentLen)
+	}
+
+	exists, err := s.repo.PostExists(ctx, postID.String())
+	if err != nil {
+		s.log.Error("post lookup failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+
+	c := DBComment{
+		ID:        uuid.NewString(),
+		PostID:    postID.String(),
+		UserID:    uid,
+		Text:      text,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond), // точность timestamptz
+	}
+	if err := s.repo.CreateComment(ctx, c); err != nil {
+		s.log.Error("create comment failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	if err := events.Produce(s.prod, s.topicCommentCreated, c.PostID, &evpb.CommentCreated{
+		Id:        c.ID,
+		PostId:    c.PostID,
+		UserId:    c.UserID,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}); err != nil {
+		s.log.Error("publish event failed", "err", err)
+	}
+
+	return &cmtpb.CreateCommentResponse{Comment: toPBComment(c)}, nil
+}
+
+func (s *Server) ListComments(ctx context.Context, req *cmtpb.ListCommentsRequest) (*cmtpb.ListCommentsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	postID, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid post_id")
+	}
+
+	// 1) limit
+	limit := uint32(20)
+	if req.Page != nil && req.Page.Limit > 0 {
+		limit = req.Page.Limit
+		if limit > 100 {
+			limit = 100
+		}
+	}
+
+	// 2) cursor -> позиция последнего отданного комментария
+	var after *Position
+	if req.Page != nil && req.Page.Cursor != nil {
+		if tok := strings.TrimSpace(req.Page.Cursor.Token); tok != "" {
+			pos, err := decodeCursor(tok)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "bad cursor")
+			}
+			after = &pos
+		}
+	}
+
+	// берём на одну строку больше, чтобы честно знать has_more
+	rows, err := s.repo.ListComments(ctx, postID.String(), limit+1, after)
+	if err != nil {
+		s.log.Error("list comments failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	hasMore := uint32(len(rows)) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	out := make([]*cmpb.Comment, 0, len(rows))
+	for _, c := range rows {
+		out = append(out, toPBComment(c))
+	}
+
+	var next *cmpb.Cursor
+	if hasMore {
+		last := rows[len(rows)-1]
+		next = &cmpb.Cursor{Token: encodeCursor(Position{CreatedAt: last.CreatedAt, ID: last.ID})}
+	}
+
+	return &cmtpb.ListCommentsResponse{
+		Comments: out,
+		PageInfo: &cmpb.PageInfo{
+			HasMore:    hasMore,
+			NextCursor: next,
+		},
+	}, nil
+}
+
+func toPBComment(c DBComment) *cmpb.Comment {
+	return &cmpb.Comment{
+		Id:        c.ID,
+		PostId:    c.PostID,
+		UserId:    c.UserID,
+		Text:      c.Text,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+// Position — место в выдаче для keyset-пагинации
+type Position struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func encodeCursor(p Position) string {
+	s := fmt.Sprintf("k:%d:%s", p.CreatedAt.UnixNano(), p.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func decodeCursor(cur string) (Position, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cur)
+	if err != nil {
+		return Position{}, err
+	}
+	parts := strings.SplitN(string(b), ":", 3)
+	if len(parts) != 3 || parts[0] != "k" {
+		return Position{}, fmt.Errorf("bad prefix")
+	}
+	ns, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return Position{}, err
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return Position{}, err
+	}
+	return Position{CreatedAt: time.Unix(0, ns).UTC(), ID: id.String()}, nil
+}
+
+func (s *Server) userIDFromAuth(ctx context.Context) (string, error) {
+	return authpkg.UserIDFromIncoming(ctx, s.jwtSecret)
+}
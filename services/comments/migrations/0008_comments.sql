@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Комментарии к постам
+CREATE TABLE IF NOT EXISTS comments (
+  id         uuid PRIMARY KEY,
+  post_id    uuid NOT NULL,
+  user_id    uuid NOT NULL,
+  text       text NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+-- Выдача комментариев поста (новые сверху) + keyset-пагинация по (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_comments_post_created
+  ON comments (post_id, created_at DESC, id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_comments_post_created;
+DROP TABLE IF EXISTS comments;
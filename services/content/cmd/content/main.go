@@ -17,6 +17,9 @@ import (
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
 	logpkg "github.com/mariapetrova3009/insta-backend/pkg/logger"
 	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/gc"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/mediahttp"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/outbox"
 	contentrepo "github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
 	contentserver "github.com/mariapetrova3009/insta-backend/services/content/internal/server"
 	contentstore "github.com/mariapetrova3009/insta-backend/services/content/internal/storage"
@@ -67,7 +70,37 @@ func main() {
 	}
 
 	repo := contentrepo.NewRepo(db)
-	store := contentstore.NewLocalFS(cfg.Storage.UploadDir)
+	urlSecret := cfg.Storage.URLSecret
+	if urlSecret == "" {
+		urlSecret = cfg.JWT.Secret
+	}
+	publicURL := cfg.Storage.PublicURL
+	if publicURL == "" {
+		publicURL = mediahttp.Prefix
+	}
+	signer := contentstore.NewURLSigner(publicURL, []byte(urlSecret))
+	local := contentstore.NewLocalFS(cfg.Storage.UploadDir, signer)
+
+	var store contentstore.Storage = local
+	if cfg.Storage.S3 {
+		s3, err := contentstore.NewS3(contentstore.S3Options{
+			Endpoint:   cfg.Storage.Endpoint,
+			Region:     cfg.Storage.Region,
+			Bucket:     cfg.Storage.Bucket,
+			AccessKey:  cfg.Storage.AccessKey,
+			SecretKey:  cfg.Storage.SecretKey,
+			PresignTTL: cfg.Storage.PresignTTL,
+		})
+		if err != nil {
+			log.Error("s3 init", "err", err)
+			return
+		}
+		log.Info("storage: s3", "endpoint", cfg.Storage.Endpoint, "bucket", cfg.Storage.Bucket)
+		store = s3
+	} else {
+		// локальные файлы отдаём сами; gateway проксирует сюда /media/
+		mux.Handle("GET "+mediahttp.Prefix, mediahttp.NewHandler(log, local, signer, repo))
+	}
 
 	// add kafka
 	prod, err := kafka.NewProducer(&kafka.ConfigMap{
@@ -76,6 +109,8 @@ func main() {
 		"acks":               "all",
 		"linger.ms":          10,
 		"retries":            5,
+		// delivery report приходит не позже этого срока; relay outbox ждёт его 15s
+		"message.timeout.ms": 10000,
 	})
 	if err != nil {
 		log.Error("kafka init", "err", err)
@@ -83,9 +118,44 @@ func main() {
 	}
 	defer prod.Close()
 
-	srv := contentserver.New(log, repo, store, prod, cfg.Kafka.Topics.PostCreated)
+	srv := contentserver.New(log, cfg, repo, store)
 	contentpb.RegisterContentServiceServer(grpcSrv, srv)
 
+	// фоновые задачи живут до shutdown
+	bgCtx, stopBg := context.WithCancel(context.Background())
+	defer stopBg()
+
+	// события из outbox -> Kafka
+	relay := outbox.New(log, repo, prod, outbox.Options{
+		Interval:  cfg.Kafka.Outbox.Interval,
+		BatchSize: cfg.Kafka.Outbox.BatchSize,
+		Retention: cfg.Kafka.Outbox.Retention,
+	})
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(bgCtx)
+	}()
+
+	// нарезка картинок на варианты
+	variantsDone := make(chan struct{})
+	go func() {
+		defer close(variantsDone)
+		srv.RunVariants(bgCtx)
+	}()
+
+	if cfg.Media.GC.Interval > 0 {
+		sweeper := gc.New(log, repo, store, gc.Options{
+			Interval:         cfg.Media.GC.Interval,
+			Grace:            cfg.Media.GC.Grace,
+			DeletedRetention: cfg.Media.GC.DeletedRetention,
+			BatchSize:        cfg.Media.GC.BatchSize,
+			DryRun:           cfg.Media.GC.DryRun,
+		})
+		log.Info("media gc enabled", "interval", cfg.Media.GC.Interval, "dry_run", cfg.Media.GC.DryRun)
+		go sweeper.Run(bgCtx)
+	}
+
 	errCh := make(chan error, 2)
 
 	go func() {
@@ -125,6 +195,9 @@ func main() {
 		log.Info("stopping", "signal", sig.String())
 	}
 
+	stopBg()
+	<-relayDone // producer закрывается после relay
+	<-variantsDone
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
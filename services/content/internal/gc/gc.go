@@ -0,0 +1,231 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/storage"
+)
+
+// DefaultGrace — если media.gc.grace не задан. Столько живёт медиа без ссылок:
+// клиент загрузил файл и ещё не создал пост (или повторил загрузку того же файла).
+const DefaultGrace = 24 * time.Hour
+
+// DefaultDeletedRetention — если media.gc.deleted_retention не задан: столько удалённый пост
+// держит своё медиа (восстановление, разбор жалоб), потом медиа освобождается
+const DefaultDeletedRetention = 30 * 24 * time.Hour
+
+const (
+	defaultInterval  = time.Hour
+	defaultBatchSize = 500
+)
+
+type Options struct {
+	Interval         time.Duration
+	Grace            time.Duration
+	DeletedRetention time.Duration // через сколько после удаления поста снимается его ссылка на медиа
+	BatchSize        int
+	DryRun           bool // только лог: что было бы удалено
+}
+
+// Report — итог одного прохода
+type Report struct {
+	PostsPurged int   // удалённых постов, отпустивших медиа (в dry-run — отпустили бы)
+	Deleted     int   // строк media удалено (в dry-run — было бы удалено)
+	Freed       int64 // байт оригиналов
+	Failed      int   // файлов, которые не удалось удалить из хранилища
+	DiskOrphans int   // файлов в хранилище без строки в БД (только отчёт)
+	ScanSkipped bool  // хранилище не умеет перечислять объекты, DiskOrphans не посчитан
+}
+
+// Sweeper периодически удаляет медиа, на которые ничего не ссылается:
+// загрузка без CreatePost, брошенный клиентом flow, сменённая аватарка,
+// пост, удалённый раньше DeletedRetention.
+type Sweeper struct {
+	log   *slog.Logger
+	repo  *repo.Repo
+	store storage.Storage
+	opt   Options
+}
+
+func New(log *slog.Logger, repo *repo.Repo, store storage.Storage, opt Options) *Sweeper {
+	if opt.Interval <= 0 {
+		opt.Interval = defaultInterval
+	}
+	if opt.Grace <= 0 {
+		opt.Grace = DefaultGrace
+	}
+	if opt.DeletedRetention <= 0 {
+		opt.DeletedRetention = DefaultDeletedRetention
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = defaultBatchSize
+	}
+	return &Sweeper{log: log.With("component", "media_gc"), repo: repo, store: store, opt: opt}
+}
+
+// Run — проход сразу и дальше раз в Interval, пока не отменят ctx
+func (s *Sweeper) Run(ctx context.Context) {
+	t := time.NewTicker(s.opt.Interval)
+	defer t.Stop()
+	for {
+		if _, err := s.Sweep(ctx); err != nil && ctx.Err() == nil {
+			s.log.Error("sweep failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Sweep — один проход: старые удалённые посты отпускают медиа, сироты из БД удаляются
+// (сначала строка, потом файлы), файлы без строки в БД только попадают в лог
+func (s *Sweeper) Sweep(ctx context.Context) (Report, error) {
+	start := time.Now()
+	cutoff := start.Add(-s.opt.Grace)
+
+	var rep Report
+	if err := s.purgeDeleted(ctx, start.Add(-s.opt.DeletedRetention), &rep); err != nil {
+		return rep, err
+	}
+	if err := s.sweepRows(ctx, cutoff, &rep); err != nil {
+		return rep, err
+	}
+	if err := s.scanStorage(ctx, cutoff, &rep); err != nil {
+		return rep, err
+	}
+
+	s.log.Info("sweep done",
+		"dry_run", s.opt.DryRun,
+		"posts_purged", rep.PostsPurged,
+		"deleted", rep.Deleted,
+		"freed_bytes", rep.Freed,
+		"failed", rep.Failed,
+		"disk_orphans", rep.DiskOrphans,
+		"scan_skipped", rep.ScanSkipped,
+		"took", time.Since(start))
+	return rep, nil
+}
+
+// purgeDeleted снимает ссылки на медиа у постов, удалённых раньше before;
+// освободившееся медиа в этом же проходе уберёт sweepRows
+func (s *Sweeper) purgeDeleted(ctx context.Context, before time.Time, rep *Report) error {
+	if s.opt.DryRun {
+		n, err := s.repo.DeletedPostsToPurge(ctx, before)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			s.log.Info("would purge deleted posts", "posts", n, "deleted_before", before)
+		}
+		rep.PostsPurged = n
+		return nil
+	}
+	for {
+		n, err := s.repo.PurgeDeletedPosts(ctx, before, s.opt.BatchSize)
+		if err != nil {
+			return err
+		}
+		rep.PostsPurged += n
+		if n < s.opt.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *Sweeper) sweepRows(ctx context.Context, cutoff time.Time, rep *Report) error {
+	after := uuid.Nil
+	for {
+		batch, err := s.repo.OrphanMedia(ctx, cutoff, after, s.opt.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, m := range batch {
+			after = m.ID
+			if s.opt.DryRun {
+				s.log.Info("would delete media", "media_id", m.ID, "path", m.Path,
+					"size", m.Size, "uploaded_at", m.UploadedAt, "variants", len(m.Variants))
+				rep.Deleted++
+				rep.Freed += m.Size
+				continue
+			}
+
+			// строку удаляем первой: файл без строки только попадёт в отчёт,
+			// а строка без файла дала бы битую ссылку
+			ok, err := s.repo.DeleteOrphanMedia(ctx, m.ID, cutoff)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue // на медиа успели сослаться
+			}
+			rep.Deleted++
+			rep.Freed += m.Size
+			for _, key := range append(m.Variants, m.Path) {
+				if err := s.store.Delete(key); err != nil {
+					s.log.Warn("delete blob failed", "key", key, "err", err)
+					rep.Failed++
+				}
+			}
+			s.log.Debug("media deleted", "media_id", m.ID, "path", m.Path)
+		}
+		if len(batch) < s.opt.BatchSize {
+			return nil
+		}
+	}
+}
+
+// scanStorage ищет файлы, для которых нет строки ни в media, ни в media_variants.
+// Свежие файлы пропускаем: загрузка может быть ещё не записана в БД.
+func (s *Sweeper) scanStorage(ctx context.Context, cutoff time.Time, rep *Report) error {
+	lister, ok := s.store.(storage.Lister)
+	if !ok {
+		// не молчим: иначе в отчёте disk_orphans=0 выглядит как «сирот нет»
+		s.log.Warn("storage scan skipped: storage cannot list objects", "storage", fmt.Sprintf("%T", s.store))
+		rep.ScanSkipped = true
+		return nil
+	}
+
+	pending := make([]string, 0, s.opt.BatchSize)
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		known, err := s.repo.KnownPaths(ctx, pending)
+		if err != nil {
+			return err
+		}
+		for _, key := range pending {
+			if !known[key] {
+				s.log.Warn("orphan file in storage", "key", key)
+				rep.DiskOrphans++
+			}
+		}
+		pending = pending[:0]
+		return nil
+	}
+
+	err := lister.Walk(func(key string, modTime time.Time) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if modTime.After(cutoff) {
+			return nil
+		}
+		pending = append(pending, key)
+		if len(pending) < s.opt.BatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
@@ -0,0 +1,127 @@
+// Package imgproc — декодирование и нарезка картинок на варианты (thumb, 640, 1080 ...).
+package imgproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // регистрация декодеров для image.Decode
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrTooLarge  = errors.New("image too large")
+	ErrEmptySize = errors.New("image has zero size")
+)
+
+// Spec — один вариант: Square — центральный квадрат Width×Width,
+// иначе ширина Width с сохранением пропорций
+type Spec struct {
+	Name   string
+	Width  int
+	Square bool
+}
+
+// Result — закодированный вариант
+type Result struct {
+	Name   string
+	Data   []byte
+	Mime   string
+	Ext    string
+	Width  int
+	Height int
+}
+
+// Decode читает картинку (jpeg/png/gif/webp), сначала проверив размеры по заголовку:
+// maxPixels > 0 ограничивает width*height, чтобы не раскрыть в память "бомбу"
+func Decode(r io.ReadSeeker, maxPixels int64) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("decode config: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrEmptySize, cfg.Width, cfg.Height)
+	}
+	if maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, "", err
+	}
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("decode %s: %w", format, err)
+	}
+	return img, format, nil
+}
+
+// Variants нарезает src по всем specs и кодирует результат
+func Variants(src image.Image, format string, specs []Spec, quality int) ([]Result, error) {
+	out := make([]Result, 0, len(specs))
+	for _, s := range specs {
+		img := Resize(src, s)
+		data, mime, ext, err := Encode(img, format, quality)
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: %w", s.Name, err)
+		}
+		b := img.Bounds()
+		out = append(out, Result{
+			Name:   s.Name,
+			Data:   data,
+			Mime:   mime,
+			Ext:    ext,
+			Width:  b.Dx(),
+			Height: b.Dy(),
+		})
+	}
+	return out, nil
+}
+
+// Resize никогда не увеличивает: маленький оригинал остаётся своего размера.
+// Пустая картинка (0 по любой стороне) возвращается пустой.
+func Resize(src image.Image, s Spec) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= 0 || b.Dy() <= 0 {
+		return image.NewRGBA(image.Rectangle{})
+	}
+	rect := b
+	w, h := min(s.Width, b.Dx()), 0
+
+	if s.Square {
+		side := min(b.Dx(), b.Dy())
+		x0 := b.Min.X + (b.Dx()-side)/2
+		y0 := b.Min.Y + (b.Dy()-side)/2
+		rect = image.Rect(x0, y0, x0+side, y0+side)
+		w = min(s.Width, side)
+		h = w
+	} else {
+		h = max(1, int(int64(b.Dy())*int64(w)/int64(b.Dx())))
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, rect, draw.Src, nil)
+	return dst
+}
+
+// Encode: png/gif (может быть прозрачность) -> PNG, остальное -> JPEG
+func Encode(img image.Image, format string, quality int) (data []byte, mime, ext string, err error) {
+	var buf bytes.Buffer
+	switch format {
+	case "png", "gif":
+		err = png.Encode(&buf, img)
+		mime, ext = "image/png", ".png"
+	default:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+		mime, ext = "image/jpeg", ".jpg"
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	return buf.Bytes(), mime, ext, nil
+}
@@ -0,0 +1,79 @@
+package imgproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+// gif с логическим экраном 0x0: заголовок, пустой screen descriptor и trailer
+var emptyGIF = []byte("GIF89a\x00\x00\x00\x00\x00\x00\x00\x3b")
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeRejectsEmpty(t *testing.T) {
+	_, _, err := Decode(bytes.NewReader(emptyGIF), 0)
+	if !errors.Is(err, ErrEmptySize) {
+		t.Fatalf("err = %v, want ErrEmptySize", err)
+	}
+}
+
+func TestDecodeTooLarge(t *testing.T) {
+	_, _, err := Decode(bytes.NewReader(encodePNG(t, 100, 100)), 100*99)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("err = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		spec         Spec
+		wantW, wantH int
+	}{
+		{"empty", 0, 0, Spec{Width: 640}, 0, 0},
+		{"empty square", 0, 0, Spec{Width: 150, Square: true}, 0, 0},
+		{"zero height", 10, 0, Spec{Width: 640}, 0, 0},
+		{"1xN", 1, 500, Spec{Width: 640}, 1, 500},
+		{"1xN square", 1, 500, Spec{Width: 150, Square: true}, 1, 1},
+		{"Nx1", 2000, 1, Spec{Width: 640}, 640, 1},
+		{"downscale", 1280, 960, Spec{Width: 640}, 640, 480},
+		{"no upscale", 320, 200, Spec{Width: 1080}, 320, 200},
+		{"square crop", 1200, 800, Spec{Width: 150, Square: true}, 150, 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := Resize(image.NewRGBA(image.Rect(0, 0, tt.w, tt.h)), tt.spec)
+			b := img.Bounds()
+			if b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Fatalf("got %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestVariantsDecodeBack(t *testing.T) {
+	src, format, err := Decode(bytes.NewReader(encodePNG(t, 1, 300)), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Variants(src, format, []Spec{{Name: "thumb", Width: 150, Square: true}, {Name: "640", Width: 640}}, 85)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range out {
+		if _, _, err := image.Decode(bytes.NewReader(v.Data)); err != nil {
+			t.Fatalf("variant %s: %v", v.Name, err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package imgproc
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Orient применяет EXIF Orientation (2..8), чтобы варианты не были повёрнуты:
+// image.Decode тег не учитывает. 0 и 1 — без изменений.
+func Orient(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	// один быстрый перевод в RGBA, дальше копируем пиксели по 4 байта
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	rgba, ok := src.(*image.RGBA)
+	if !ok || rgba.Rect.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(rgba, rgba.Rect, src, b.Min, draw.Src)
+	}
+
+	// 5..8 меняют местами ширину и высоту
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // зеркально по горизонтали
+				dx, dy = w-1-x, y
+			case 3: // 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // зеркально по вертикали
+				dx, dy = x, h-1-y
+			case 5: // транспонирование
+				dx, dy = y, x
+			case 6: // 90° по часовой
+				dx, dy = h-1-y, x
+			case 7: // транспонирование через другую диагональ
+				dx, dy = h-1-y, w-1-x
+			case 8: // 90° против часовой
+				dx, dy = y, w-1-x
+			}
+			si := y*rgba.Stride + x*4
+			di := dy*dst.Stride + dx*4
+			copy(dst.Pix[di:di+4], rgba.Pix[si:si+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,92 @@
+// Package mediahttp отдаёт файлы LocalFS по подписанным ссылкам из LocalFS.Presign.
+// Для S3 не нужен: там ссылки ведут прямо в бакет.
+package mediahttp
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/storage"
+)
+
+// Prefix — путь, под которым монтируется Handler (и база ссылок по умолчанию)
+const Prefix = "/media/"
+
+// Handler: GET/HEAD /media/{key}?token=<exp>.<sig>
+// Range, ETag/If-None-Match и If-Modified-Since — через http.ServeContent.
+type Handler struct {
+	log    *slog.Logger
+	fs     *storage.LocalFS
+	signer *storage.URLSigner
+	repo   *repo.Repo
+}
+
+func NewHandler(log *slog.Logger, fs *storage.LocalFS, signer *storage.URLSigner, repo *repo.Repo) *Handler {
+	return &Handler{log: log, fs: fs, signer: signer, repo: repo}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, Prefix)
+	if key == "" || key == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+
+	// 0) токен: подпись и срок
+	exp, err := h.signer.Verify(key, r.URL.Query().Get("token"), time.Now())
+	if err != nil {
+		// причину (истёк срок, плохая подпись) клиенту не показываем — только в лог
+		h.log.Warn("media token rejected", "key", key, "err", err)
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	// 1) файл
+	f, err := h.fs.Open(key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		h.log.Error("media open failed", "key", key, "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	// 2) Content-Type — тот, что записан при загрузке; иначе ServeContent угадает сам
+	mime, err := h.repo.MediaMime(r.Context(), key)
+	switch {
+	case err == nil:
+		w.Header().Set("Content-Type", mime)
+	case !errors.Is(err, sql.ErrNoRows):
+		h.log.Warn("media mime lookup failed", "key", key, "err", err)
+	}
+
+	// 3) файл под ключом не меняется, поэтому кэшируем до конца жизни ссылки
+	maxAge := int(time.Until(exp).Seconds())
+	w.Header().Set("ETag", etag(fi))
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d, immutable", maxAge))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, fi.Name(), fi.ModTime(), f)
+}
+
+// etag — сильный ETag из размера и mtime
+func etag(fi os.FileInfo) string {
+	return `"` + strconv.FormatInt(fi.Size(), 36) + "-" + strconv.FormatInt(fi.ModTime().UnixNano(), 36) + `"`
+}
@@ -0,0 +1,154 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
+)
+
+const (
+	defaultInterval  = time.Second
+	defaultBatchSize = 100
+	defaultRetention = 7 * 24 * time.Hour
+
+	// сколько ждём delivery report на пачку; producer настроен отдавать его раньше
+	// (message.timeout.ms), так что по таймауту почти всегда значит "Kafka недоступна"
+	deliveryTimeout = 15 * time.Second
+
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+
+	pruneEvery = time.Hour
+)
+
+var errDeliveryTimeout = errors.New("delivery report timeout")
+
+type Options struct {
+	Interval  time.Duration // пауза, когда отправлять нечего
+	BatchSize int
+	Retention time.Duration // сколько хранить отправленные строки
+}
+
+// Relay отправляет события из таблицы outbox в Kafka: at-least-once,
+// повтор с экспоненциальной паузой, пока Kafka не примет сообщение
+type Relay struct {
+	log  *slog.Logger
+	repo *repo.Repo
+	prod *kafka.Producer
+	opt  Options
+}
+
+func New(log *slog.Logger, repo *repo.Repo, prod *kafka.Producer, opt Options) *Relay {
+	if opt.Interval <= 0 {
+		opt.Interval = defaultInterval
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = defaultBatchSize
+	}
+	if opt.Retention <= 0 {
+		opt.Retention = defaultRetention
+	}
+	return &Relay{log: log.With("component", "outbox"), repo: repo, prod: prod, opt: opt}
+}
+
+// Run крутится до отмены ctx: полная пачка — сразу следующая, иначе ждём Interval
+func (r *Relay) Run(ctx context.Context) {
+	var lastPrune time.Time
+	for {
+		n, err := r.repo.ProcessOutbox(ctx, r.opt.BatchSize, r.send, backoff)
+		if err != nil && ctx.Err() == nil {
+			r.log.Error("process outbox failed", "err", err)
+		}
+
+		if time.Since(lastPrune) > pruneEvery {
+			lastPrune = time.Now()
+			if pruned, err := r.repo.PruneOutbox(ctx, lastPrune.Add(-r.opt.Retention)); err != nil {
+				r.log.Warn("prune outbox failed", "err", err)
+			} else if pruned > 0 {
+				r.log.Debug("outbox pruned", "rows", pruned)
+			}
+		}
+
+		if err == nil && n == r.opt.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.opt.Interval):
+		}
+	}
+}
+
+// send отдаёт пачку в producer и ждёт delivery report по каждому сообщению
+func (r *Relay) send(batch []repo.OutboxMessage) []error {
+	errs := make([]error, len(batch))
+	// буфер на всю пачку: опоздавшие отчёты после таймаута не блокируют producer
+	delivery := make(chan kafka.Event, len(batch))
+	index := make(map[int64]int, len(batch))
+
+	waiting := 0
+	for i := range batch {
+		m := &batch[i]
+		msg := &kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &m.Topic, Partition: kafka.PartitionAny},
+			Key:            m.Key,
+			Value:          m.Payload,
+			Opaque:         m.ID,
+		}
+		for k, v := range m.Headers {
+			msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		if err := r.prod.Produce(msg, delivery); err != nil {
+			errs[i] = err
+			continue
+		}
+		index[m.ID] = i
+		waiting++
+	}
+
+	timeout := time.NewTimer(deliveryTimeout)
+	defer timeout.Stop()
+	for waiting > 0 {
+		select {
+		case e := <-delivery:
+			m, ok := e.(*kafka.Message)
+			if !ok {
+				continue
+			}
+			i, ok := index[m.Opaque.(int64)]
+			if !ok {
+				continue
+			}
+			delete(index, m.Opaque.(int64))
+			errs[i] = m.TopicPartition.Error
+			waiting--
+		case <-timeout.C:
+			for _, i := range index {
+				errs[i] = errDeliveryTimeout
+			}
+			waiting = 0
+		}
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			r.log.Warn("outbox delivery failed", "id", batch[i].ID, "topic", batch[i].Topic,
+				"attempt", batch[i].Attempts+1, "err", err)
+		}
+	}
+	return errs
+}
+
+// backoff: 1s, 2s, 4s ... до maxBackoff
+func backoff(attempts int) time.Duration {
+	d := minBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// OrphanMedia — медиа, на которое не ссылается ни пост, ни аватарка
+type OrphanMedia struct {
+	ID         uuid.UUID
+	Path       string
+	Size       int64
+	UploadedAt time.Time
+	Variants   []string // пути вариантов в хранилище
+}
+
+// orphanCond — общее условие "сироты": ref_count мог разойтись со ссылками
+// (данные до миграции, ручные правки), поэтому ссылки проверяем и напрямую;
+// удалённый пост тоже ссылка, пока его не вычистил PurgeDeletedPosts
+const orphanCond = `m.ref_count = 0 AND m.uploaded_at < $1
+	AND NOT EXISTS (SELECT 1 FROM posts p WHERE p.media_id = m.id)
+	AND NOT EXISTS (SELECT 1 FROM users u WHERE u.avatar_path = m.path)`
+
+// OrphanMedia — медиа без ссылок, загруженные раньше before; страница по id после afterID
+// (uuid.Nil — с начала), не больше limit
+func (r *Repo) OrphanMedia(ctx context.Context, before time.Time, afterID uuid.UUID, limit int) ([]OrphanMedia, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT m.id, m.path, m.size, m.uploaded_at,
+		COALESCE(array_agg(v.path) FILTER (WHERE v.path IS NOT NULL), '{}')
+	FROM media m LEFT JOIN media_variants v ON v.media_id = m.id
+	WHERE `+orphanCond+` AND m.id > $2
+	GROUP BY m.id
+	ORDER BY m.id
+	LIMIT $3`, before, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []OrphanMedia
+	for rows.Next() {
+		var m OrphanMedia
+		if err := rows.Scan(&m.ID, &m.Path, &m.Size, &m.UploadedAt, pq.Array(&m.Variants)); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// DeleteOrphanMedia удаляет строку медиа (варианты — каскадом), только если она всё ещё сирота:
+// между выборкой и удалением на неё могли сослаться. false — строка осталась.
+func (r *Repo) DeleteOrphanMedia(ctx context.Context, id uuid.UUID, before time.Time) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM media m WHERE `+orphanCond+` AND m.id = $2`, before, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// purgeableCond — удалённые раньше $1 посты, которые ещё держат медиа
+const purgeableCond = `deleted_at < $1 AND media_id IS NOT NULL`
+
+// PurgeDeletedPosts отвязывает медиа от постов, удалённых раньше before (не больше limit за раз),
+// и снимает их ссылки. Строка поста остаётся; медиа без ссылок дальше убирает обычный проход
+// сборщика. Возвращает, сколько постов обработано.
+func (r *Repo) PurgeDeletedPosts(ctx context.Context, before time.Time, limit int) (int, error) {
+	var n int
+	err := r.DB.QueryRowContext(ctx,
+		`WITH purged AS (
+		SELECT id, media_id FROM posts
+		WHERE `+purgeableCond+`
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	), detached AS (
+		UPDATE posts p SET media_id = NULL
+		FROM purged WHERE p.id = purged.id
+		RETURNING purged.media_id
+	), released AS (
+		-- правило mediaref.ReleasePath, пачкой: -1 за каждый пост, не ниже нуля
+		UPDATE media m SET ref_count = GREATEST(m.ref_count - d.n, 0)
+		FROM (SELECT media_id, count(*) AS n FROM detached GROUP BY media_id) d
+		WHERE m.id = d.media_id
+	)
+	SELECT count(*) FROM detached`, before, limit).Scan(&n)
+	return n, err
+}
+
+// DeletedPostsToPurge — сколько постов обработал бы PurgeDeletedPosts (для dry-run)
+func (r *Repo) DeletedPostsToPurge(ctx context.Context, before time.Time) (int, error) {
+	var n int
+	err := r.DB.QueryRowContext(ctx, `SELECT count(*) FROM posts WHERE `+purgeableCond, before).Scan(&n)
+	return n, err
+}
+
+// KnownPaths — какие из путей есть в media или media_variants
+func (r *Repo) KnownPaths(ctx context.Context, paths []string) (map[string]bool, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT path FROM media WHERE path = ANY($1)
+	UNION
+	SELECT path FROM media_variants WHERE path = ANY($1)`, pq.Array(paths))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	known := make(map[string]bool, len(paths))
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		known[p] = true
+	}
+	return known, rows.Err()
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// OutboxMessage — событие, ждущее отправки в Kafka
+type OutboxMessage struct {
+	ID       int64
+	Topic    string
+	Key      []byte
+	Payload  []byte
+	Headers  map[string]string
+	Attempts int // сколько раз уже пытались отправить
+}
+
+func insertOutbox(ctx context.Context, tx *sql.Tx, m *OutboxMessage) error {
+	headers, err := json.Marshal(m.Headers)
+	if err != nil {
+		return err
+	}
+	return tx.QueryRowContext(ctx,
+		`INSERT INTO outbox (topic, key, payload, headers) VALUES ($1, $2, $3, $4) RETURNING id`,
+		m.Topic, m.Key, m.Payload, headers).Scan(&m.ID)
+}
+
+// ProcessOutbox берёт до limit сообщений, которым пора уйти, и отдаёт их в send.
+// Строки заблокированы до конца обработки (SKIP LOCKED — реплики content не отправят одно и то же).
+// send возвращает ошибку на каждое сообщение (nil — доставлено); неудачные
+// откладываются на backoff(attempts). Возвращает число выбранных сообщений.
+func (r *Repo) ProcessOutbox(ctx context.Context, limit int,
+	send func([]OutboxMessage) []error, backoff func(attempts int) time.Duration) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, topic, key, payload, headers, attempts
+	FROM outbox
+	WHERE sent_at IS NULL AND next_attempt_at <= now()
+	ORDER BY next_attempt_at, id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, err
+	}
+	var batch []OutboxMessage
+	for rows.Next() {
+		var (
+			m       OutboxMessage
+			headers []byte
+		)
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &m.Payload, &headers, &m.Attempts); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if err := json.Unmarshal(headers, &m.Headers); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	errs := send(batch)
+
+	var sent []int64
+	for i, m := range batch {
+		if errs[i] == nil {
+			sent = append(sent, m.ID)
+			continue
+		}
+		next := time.Now().Add(backoff(m.Attempts + 1))
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`,
+			m.ID, errs[i].Error(), next); err != nil {
+			return 0, err
+		}
+	}
+	if len(sent) > 0 {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE outbox SET sent_at = now(), last_error = NULL WHERE id = ANY($1)`, pq.Array(sent)); err != nil {
+			return 0, err
+		}
+	}
+	return len(batch), tx.Commit()
+}
+
+// PruneOutbox удаляет отправленные сообщения старше before
+func (r *Repo) PruneOutbox(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM outbox WHERE sent_at IS NOT NULL AND sent_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -3,25 +3,50 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mariapetrova3009/insta-backend/pkg/mediaref"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrNotAuthor     = errors.New("not the author")
+	ErrMediaNotFound = errors.New("media not found")
 )
 
 type Media struct {
-	ID        uuid.UUID
-	Path      string
-	Mime      string
-	Size      int64
-	CreatedAt time.Time
+	ID          uuid.UUID
+	Path        string
+	Mime        string
+	Size        int64
+	CreatedAt   time.Time
+	SanitizedAt sql.NullTime // когда вычищены метаданные (EXIF/XMP/IPTC)
+	SHA256      string       // hex; пусто у медиа, загруженных до дедупликации
+	UploadedAt  time.Time    // последняя загрузка этого содержимого
 }
 
 type Post struct {
-	ID        uuid.UUID
-	AuthorID  uuid.UUID
-	Caption   string
-	Media     Media
-	CreatedAt time.Time
+	ID            uuid.UUID
+	AuthorID      uuid.UUID
+	Caption       string
+	Media         Media
+	LikesCount    int64
+	CommentsCount int64
+	LikedByMe     bool // лайкнул ли пост viewer из запроса
+	CreatedAt     time.Time
+	Variants      []Variant // уменьшенные копии медиа, от меньшей к большей
+}
+
+type Variant struct {
+	Name   string
+	Path   string
+	Mime   string
+	Width  int
+	Height int
+	Size   int64
 }
 
 type Repo struct {
@@ -32,54 +57,252 @@ func NewRepo(db *sql.DB) *Repo {
 	return &Repo{DB: db}
 }
 
-func (r *Repo) CreateMedia(ctx context.Context, m *Media) error {
-	stmt, err := r.DB.PrepareContext(ctx, `INSERT INTO media (id, path, mime, size, created_at) 
-	VALUES ($1, $2, $3, $4, $5)`)
+// SaveMedia записывает медиа или, если файл с таким sha256 уже есть, возвращает
+// существующую строку в m (created=false) и сдвигает её uploaded_at.
+// Уникальный индекс по sha256 решает гонку одинаковых загрузок.
+// uploaderID запоминается в media_uploads (см. mediaref.UploadedBy).
+func (r *Repo) SaveMedia(ctx context.Context, m *Media, uploaderID uuid.UUID) (created bool, err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO media (id, path, mime, size, created_at, sanitized_at, sha256, uploaded_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (sha256) DO UPDATE SET uploaded_at = EXCLUDED.uploaded_at
+	RETURNING id, path, mime, size, created_at, sanitized_at, (xmax = 0)`,
+		m.ID, m.Path, m.Mime, m.Size, m.CreatedAt, m.SanitizedAt, m.SHA256, m.UploadedAt).
+		Scan(&m.ID, &m.Path, &m.Mime, &m.Size, &m.CreatedAt, &m.SanitizedAt, &created)
+	if err != nil {
+		return false, err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO media_uploads (media_id, user_id, uploaded_at) VALUES ($1, $2, $3)
+	ON CONFLICT (media_id, user_id) DO UPDATE SET uploaded_at = EXCLUDED.uploaded_at`,
+		m.ID, uploaderID, m.UploadedAt)
+	if err != nil {
+		return false, err
+	}
+	return created, tx.Commit()
+}
+
+// MediaMime — mime файла (оригинала или варианта) по его пути в хранилище
+// (sql.ErrNoRows, если такого нет)
+func (r *Repo) MediaMime(ctx context.Context, path string) (string, error) {
+	var mime string
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT mime FROM media WHERE path = $1
+	UNION ALL
+	SELECT mime FROM media_variants WHERE path = $1
+	LIMIT 1`, path).Scan(&mime)
+	return mime, err
+}
+
+// CreateVariants записывает варианты одного медиа одной транзакцией
+func (r *Repo) CreateVariants(ctx context.Context, mediaID uuid.UUID, vs []Variant) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO media_variants (media_id, name, path, mime, width, height, size)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
-	_, err = stmt.ExecContext(ctx, m.ID, m.Path, m.Mime, m.Size, m.CreatedAt)
+	for _, v := range vs {
+		if _, err := stmt.ExecContext(ctx, mediaID, v.Name, v.Path, v.Mime, v.Width, v.Height, v.Size); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// attachVariants дочитывает варианты медиа для пачки постов одним запросом
+func (r *Repo) attachVariants(ctx context.Context, posts []*Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	byMedia := make(map[uuid.UUID][]*Post, len(posts))
+	ids := make([]string, 0, len(posts))
+	for _, p := range posts {
+		if _, ok := byMedia[p.Media.ID]; !ok {
+			ids = append(ids, p.Media.ID.String())
+		}
+		byMedia[p.Media.ID] = append(byMedia[p.Media.ID], p)
+	}
+
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT media_id, name, path, mime, width, height, size
+	FROM media_variants
+	WHERE media_id = ANY($1::uuid[])
+	ORDER BY media_id, width`, pq.Array(ids))
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
-	return nil
+	for rows.Next() {
+		var (
+			mediaID uuid.UUID
+			v       Variant
+		)
+		if err := rows.Scan(&mediaID, &v.Name, &v.Path, &v.Mime, &v.Width, &v.Height, &v.Size); err != nil {
+			return err
+		}
+		for _, p := range byMedia[mediaID] {
+			p.Variants = append(p.Variants, v)
+		}
+	}
+	return rows.Err()
 }
 
-func (r *Repo) CreatePost(ctx context.Context, p *Post) error {
-	stmt, err := r.DB.PrepareContext(ctx, `insert into posts(id, author_id, caption, media_id, created_at) values($1,$2,$3,$4,$5)`)
+// CreatePost вставляет пост и в той же транзакции добавляет ссылку на его медиа
+// и кладёт событие evt в outbox (nil — без события); p.Media.Path/Mime заполняет из media.
+// ErrMediaNotFound — нет такого медиа.
+func (r *Repo) CreatePost(ctx context.Context, p *Post, evt *OutboxMessage) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
-	_, err = stmt.ExecContext(ctx, p.ID, p.AuthorID, p.Caption, p.Media.ID, p.CreatedAt)
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `insert into posts(id, author_id, caption, media_id, created_at) values($1,$2,$3,$4,$5)`,
+		p.ID, p.AuthorID, p.Caption, p.Media.ID, p.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+		return ErrMediaNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := mediaref.Acquire(ctx, tx, p.Media.ID); err != nil {
+		return err
+	}
+	// путь и mime — из строки media (mime там по сниффингу), а не со слов клиента
+	err = tx.QueryRowContext(ctx, `SELECT path, mime FROM media WHERE id = $1`, p.Media.ID).
+		Scan(&p.Media.Path, &p.Media.Mime)
 	if err != nil {
 		return err
 	}
+	if evt != nil {
+		if err := insertOutbox(ctx, tx, evt); err != nil {
+			return err
+		}
+	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (r *Repo) GetPost(ctx context.Context, id uuid.UUID) (*Post, error) {
+// DeletePost мягко удаляет пост автора authorID: ставит deleted_at и кладёт событие evt
+// в outbox одной транзакцией. Ссылка на медиа остаётся (пост можно восстановить) —
+// её снимет сборщик после срока хранения, см. PurgeDeletedPosts. Повторное удаление — ErrNotFound.
+func (r *Repo) DeletePost(ctx context.Context, id, authorID uuid.UUID, evt *OutboxMessage) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var owner uuid.UUID
+	err = tx.QueryRowContext(ctx,
+		`SELECT author_id FROM posts WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&owner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if owner != authorID {
+		return ErrNotAuthor
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET deleted_at = now() WHERE id = $1`, id); err != nil {
+		return err
+	}
+	if evt != nil {
+		if err := insertOutbox(ctx, tx, evt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetPost читает пост с медиа и счётчиками; liked_by_me считается для viewerID
+// (uuid.Nil — анонимный запрос, всегда false)
+func (r *Repo) GetPost(ctx context.Context, id, viewerID uuid.UUID) (*Post, error) {
 	stmt, err := r.DB.PrepareContext(ctx,
-		`SELECT p.id, p.author_id, p.caption, p.created_at, 
-		m.id, m.path, m.mime, m.size, m.created_at 
+		`SELECT p.id, p.author_id, p.caption, p.likes_count, p.comments_count, p.created_at,
+		m.id, m.path, m.mime, m.size, m.created_at,
+		EXISTS (SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $2)
 	FROM posts p JOIN media m 
 	ON m.id = p.media_id
-	where p.id = $1`)
+	where p.id = $1 AND p.deleted_at IS NULL`)
 	var p Post
 	p.Media = Media{}
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
-	row := stmt.QueryRowContext(ctx, id)
-	if err := row.Scan(&p.ID, &p.AuthorID, &p.Caption, &p.CreatedAt,
-		&p.Media.ID, &p.Media.Path, &p.Media.Mime, &p.Media.Size, &p.Media.CreatedAt); err != nil {
+	row := stmt.QueryRowContext(ctx, id, viewerID)
+	if err := row.Scan(&p.ID, &p.AuthorID, &p.Caption, &p.LikesCount, &p.CommentsCount, &p.CreatedAt,
+		&p.Media.ID, &p.Media.Path, &p.Media.Mime, &p.Media.Size, &p.Media.CreatedAt, &p.LikedByMe); err != nil {
 		return nil, err
 
 	}
+	if err := r.attachVariants(ctx, []*Post{&p}); err != nil {
+		return nil, err
+	}
 
 	return &p, nil
 }
+
+// GetPosts читает пачку постов одним запросом (WHERE id = ANY($1));
+// несуществующие и удалённые id просто отсутствуют в ответе, порядок не гарантируется
+func (r *Repo) GetPosts(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) ([]Post, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	strIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strIDs = append(strIDs, id.String())
+	}
+
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT p.id, p.author_id, p.caption, p.likes_count, p.comments_count, p.created_at,
+		m.id, m.path, m.mime, m.size, m.created_at,
+		EXISTS (SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $2)
+	FROM posts p JOIN media m
+	ON m.id = p.media_id
+	WHERE p.id = ANY($1::uuid[]) AND p.deleted_at IS NULL`, pq.Array(strIDs), viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]Post, 0, len(ids))
+	for rows.Next() {
+		var p Post
+		if err := rows.Scan(&p.ID, &p.AuthorID, &p.Caption, &p.LikesCount, &p.CommentsCount, &p.CreatedAt,
+			&p.Media.ID, &p.Media.Path, &p.Media.Mime, &p.Media.Size, &p.Media.CreatedAt, &p.LikedByMe); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Post, len(out))
+	for i := range out {
+		ptrs[i] = &out[i]
+	}
+	if err := r.attachVariants(ctx, ptrs); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package sanitize
+
+import "encoding/binary"
+
+const tagOrientation = 0x0112
+
+// exifOrientation достаёт Orientation из IFD0 TIFF-структуры EXIF; 0 — не нашли
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var bo binary.ByteOrder
+	switch string(tiff[:4]) {
+	case "II*\x00":
+		bo = binary.LittleEndian
+	case "MM\x00*":
+		bo = binary.BigEndian
+	default:
+		return 0
+	}
+	off := int(bo.Uint32(tiff[4:8]))
+	if off < 8 || off+2 > len(tiff) {
+		return 0
+	}
+	n := int(bo.Uint16(tiff[off:]))
+	for i := 0; i < n; i++ {
+		e := off + 2 + i*12
+		if e+12 > len(tiff) {
+			return 0
+		}
+		if bo.Uint16(tiff[e:]) != tagOrientation {
+			continue
+		}
+		// SHORT, count 1: значение в первых двух байтах поля value
+		if v := int(bo.Uint16(tiff[e+8:])); v >= 1 && v <= 8 {
+			return v
+		}
+		return 0
+	}
+	return 0
+}
+
+// minimalTIFF — TIFF-структура EXIF с единственным тегом Orientation
+func minimalTIFF(orientation int) []byte {
+	b := make([]byte, 0, 26)
+	b = append(b, 'M', 'M', 0, '*') // big-endian
+	b = binary.BigEndian.AppendUint32(b, 8)
+	b = binary.BigEndian.AppendUint16(b, 1) // одна запись
+	b = binary.BigEndian.AppendUint16(b, tagOrientation)
+	b = binary.BigEndian.AppendUint16(b, 3) // SHORT
+	b = binary.BigEndian.AppendUint32(b, 1)
+	b = binary.BigEndian.AppendUint16(b, uint16(orientation))
+	b = append(b, 0, 0)                     // добивка поля value до 4 байт
+	b = binary.BigEndian.AppendUint32(b, 0) // следующего IFD нет
+	return b
+}
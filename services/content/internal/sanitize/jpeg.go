@@ -0,0 +1,172 @@
+package sanitize
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+const (
+	markerSOI   = 0xD8
+	markerEOI   = 0xD9
+	markerSOS   = 0xDA
+	markerAPP0  = 0xE0
+	markerAPP1  = 0xE1
+	markerAPP2  = 0xE2
+	markerAPP14 = 0xEE // Adobe — нужен для правильных цветов
+	markerCOM   = 0xFE
+)
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	iccHeader  = []byte("ICC_PROFILE\x00")
+)
+
+// jpeg: SOI, сегменты до SOS, энтропийные данные, ... EOI; всё после EOI отбрасываем
+// (туда камеры дописывают MPF-превью и свои трейлеры)
+func (s *Stream) jpeg(r *bufio.Reader, w *bufio.Writer) error {
+	if _, err := r.Discard(2); err != nil { // SOI
+		return malformed(err)
+	}
+	w.Write([]byte{0xFF, markerSOI})
+
+	var (
+		m       byte
+		err     error
+		pending bool // маркер уже прочитан в copyScan
+	)
+	for {
+		if !pending {
+			if m, err = nextMarker(r); err != nil {
+				return malformed(err)
+			}
+		}
+		pending = false
+
+		switch {
+		case m == markerEOI:
+			w.Write([]byte{0xFF, markerEOI})
+			return nil
+		case m == 0x01 || (m >= 0xD0 && m <= 0xD7): // без длины
+			w.Write([]byte{0xFF, m})
+			continue
+		}
+
+		var lenBuf [2]byte
+		if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+			return malformed(err)
+		}
+		n := int(binary.BigEndian.Uint16(lenBuf[:]))
+		if n < 2 {
+			return ErrMalformed
+		}
+		payload := make([]byte, n-2)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return malformed(err)
+		}
+
+		if keep, what := s.keepSegment(m, payload); !keep {
+			s.removed(what)
+			// вместо EXIF — минимальный EXIF с одной ориентацией
+			if what == "exif" && s.res.Orientation > 1 {
+				writeSegment(w, markerAPP1, append(append([]byte{}, exifHeader...), minimalTIFF(s.res.Orientation)...))
+			}
+			continue
+		}
+		writeSegment(w, m, payload)
+
+		if m == markerSOS {
+			if m, err = copyScan(r, w); err != nil {
+				return malformed(err)
+			}
+			pending = true
+		}
+	}
+}
+
+// keepSegment решает судьбу APPn/COM; what — что именно выкидываем
+func (s *Stream) keepSegment(m byte, payload []byte) (keep bool, what string) {
+	switch {
+	case m == markerCOM:
+		return false, "comment"
+	case m == markerAPP1 && bytes.HasPrefix(payload, exifHeader):
+		if o := exifOrientation(payload[len(exifHeader):]); o != 0 {
+			s.res.Orientation = o
+		}
+		return false, "exif"
+	case m == markerAPP1:
+		return false, "xmp"
+	case m == 0xED: // APP13: Photoshop / IPTC
+		return false, "iptc"
+	case m == markerAPP0, m == markerAPP14:
+		return true, ""
+	case m == markerAPP2 && bytes.HasPrefix(payload, iccHeader):
+		return true, ""
+	case m >= markerAPP0 && m <= 0xEF:
+		return false, "app"
+	default: // DQT, DHT, SOFn, DRI, SOS ...
+		return true, ""
+	}
+}
+
+// nextMarker пропускает заполняющие 0xFF и возвращает код маркера
+func nextMarker(r *bufio.Reader) (byte, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b != 0xFF {
+		return 0, ErrMalformed
+	}
+	for {
+		m, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if m != 0xFF {
+			return m, nil
+		}
+	}
+}
+
+// copyScan копирует энтропийные данные после SOS до следующего маркера
+// (0xFF00 — экранированный байт, RSTn — часть скана) и возвращает код этого маркера
+func copyScan(r *bufio.Reader, w *bufio.Writer) (byte, error) {
+	for {
+		chunk, err := r.ReadSlice(0xFF)
+		if err == bufio.ErrBufferFull {
+			if _, err := w.Write(chunk); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if _, err := w.Write(chunk[:len(chunk)-1]); err != nil {
+			return 0, err
+		}
+
+		m, err := r.ReadByte()
+		for err == nil && m == 0xFF { // заполнение
+			m, err = r.ReadByte()
+		}
+		if err != nil {
+			return 0, err
+		}
+		if m == 0x00 || (m >= 0xD0 && m <= 0xD7) {
+			w.Write([]byte{0xFF, m})
+			continue
+		}
+		return m, nil
+	}
+}
+
+func writeSegment(w *bufio.Writer, m byte, payload []byte) {
+	var hdr [4]byte
+	hdr[0], hdr[1] = 0xFF, m
+	binary.BigEndian.PutUint16(hdr[2:], uint16(len(payload)+2))
+	w.Write(hdr[:])
+	w.Write(payload)
+}
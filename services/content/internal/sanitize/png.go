@@ -0,0 +1,83 @@
+package sanitize
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// вспомогательные чанки, которые нужны для отображения; остальные
+// вспомогательные (tEXt, zTXt, iTXt, tIME, eXIf, приватные) выкидываем
+var pngKeep = map[string]bool{
+	"tRNS": true, "gAMA": true, "cHRM": true, "sRGB": true, "iCCP": true,
+	"sBIT": true, "bKGD": true, "pHYs": true, "hIST": true, "sPLT": true,
+	"cICP": true, "mDCv": true, "cLLi": true,
+	"acTL": true, "fcTL": true, "fdAT": true, // APNG
+}
+
+// максимальный eXIf, который читаем в память ради ориентации
+const maxPNGExif = 1 << 20
+
+// png: сигнатура, чанки до IEND; всё после IEND отбрасываем
+func (s *Stream) png(r *bufio.Reader, w *bufio.Writer) error {
+	if _, err := r.Discard(len(pngSignature)); err != nil {
+		return malformed(err)
+	}
+	w.Write(pngSignature)
+
+	var hdr [8]byte
+	for {
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return malformed(err)
+		}
+		n := int64(binary.BigEndian.Uint32(hdr[:4]))
+		typ := string(hdr[4:8])
+		critical := typ[0] >= 'A' && typ[0] <= 'Z'
+
+		switch {
+		case critical || pngKeep[typ]:
+			// данные + CRC как есть
+			w.Write(hdr[:])
+			if _, err := io.CopyN(w, r, n+4); err != nil {
+				return malformed(err)
+			}
+			if typ == "IEND" {
+				return nil
+			}
+		case typ == "eXIf" && n <= maxPNGExif:
+			data := make([]byte, n)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return malformed(err)
+			}
+			if _, err := r.Discard(4); err != nil { // CRC
+				return malformed(err)
+			}
+			s.removed("exif")
+			if o := exifOrientation(data); o > 1 {
+				s.res.Orientation = o
+				writeChunk(w, "eXIf", minimalTIFF(o))
+			}
+		default:
+			if _, err := r.Discard(int(n + 4)); err != nil {
+				return malformed(err)
+			}
+			s.removed(typ)
+		}
+	}
+}
+
+func writeChunk(w *bufio.Writer, typ string, data []byte) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(len(data)))
+	w.Write(b[:])
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	w.Write([]byte(typ))
+	w.Write(data)
+	binary.BigEndian.PutUint32(b[:], crc.Sum32())
+	w.Write(b[:])
+}
@@ -0,0 +1,94 @@
+// Package sanitize вырезает из JPEG и PNG метаданные (EXIF с GPS и серийниками,
+// XMP, IPTC, комментарии) потоком, не перекодируя картинку.
+//
+// Ориентацию из EXIF сохраняем: вместо исходного EXIF пишем минимальный,
+// только с тегом Orientation, чтобы фото не показывалось повёрнутым.
+// Остальные форматы проходят как есть.
+package sanitize
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// ErrMalformed — файл похож на JPEG/PNG, но разобрать его не получилось
+var ErrMalformed = errors.New("malformed image")
+
+// Result — что сделали с файлом
+type Result struct {
+	Format      string   // "jpeg", "png"; пусто — формат не наш, файл не трогали
+	Orientation int      // EXIF Orientation 1..8 (0 — тега не было)
+	Removed     []string // вырезанные сегменты/чанки: "exif", "xmp", "iptc", "tEXt" ...
+}
+
+// Sanitized — файл прошёл через фильтр
+func (r Result) Sanitized() bool { return r.Format != "" }
+
+// Stream — фильтр поверх src. Читать до EOF, затем Close и Result.
+type Stream struct {
+	pr   *io.PipeReader
+	done chan struct{}
+	res  Result
+}
+
+func New(src io.Reader) *Stream {
+	pr, pw := io.Pipe()
+	s := &Stream{pr: pr, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		w := bufio.NewWriterSize(pw, 32<<10)
+		err := s.filter(bufio.NewReaderSize(src, 32<<10), w)
+		if err == nil {
+			err = w.Flush()
+		}
+		pw.CloseWithError(err) // nil -> EOF для читателя
+	}()
+	return s
+}
+
+func (s *Stream) Read(p []byte) (int, error) { return s.pr.Read(p) }
+
+// Close останавливает фильтр (если читатель бросил поток раньше) и ждёт его
+func (s *Stream) Close() error {
+	s.pr.CloseWithError(io.ErrClosedPipe)
+	<-s.done
+	return nil
+}
+
+// Result валиден после Close
+func (s *Stream) Result() Result { return s.res }
+
+func (s *Stream) filter(r *bufio.Reader, w *bufio.Writer) error {
+	head, _ := r.Peek(8)
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		s.res.Format = "jpeg"
+		return s.jpeg(r, w)
+	case bytes.Equal(head, pngSignature):
+		s.res.Format = "png"
+		return s.png(r, w)
+	default:
+		_, err := io.Copy(w, r)
+		return err
+	}
+}
+
+func (s *Stream) removed(what string) {
+	for _, x := range s.res.Removed {
+		if x == what {
+			return
+		}
+	}
+	s.res.Removed = append(s.res.Removed, what)
+}
+
+// malformed: обрыв посреди структуры — ErrMalformed, прочие ошибки чтения (лимит размера,
+// отмена стрима) пробрасываем как есть
+func malformed(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrMalformed
+	}
+	return err
+}
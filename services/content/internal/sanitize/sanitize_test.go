@@ -0,0 +1,289 @@
+package sanitize
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+)
+
+// секреты, которых не должно остаться в выходе
+var (
+	gpsSecret       = []byte("GPS-SECRET-55.7558N")
+	makerNoteSecret = []byte("MAKERNOTE-SECRET-SN123")
+	trailerSecret   = []byte("TRAILER-SECRET")
+	xmpSecret       = []byte("XMP-SECRET")
+	textSecret      = []byte("TEXT-SECRET")
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 32; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 8), uint8(y * 16), 128, 255})
+		}
+	}
+	return img
+}
+
+// cameraTIFF — EXIF как у камеры: Orientation, GPS IFD и MakerNote в IFD0, данные следом
+func cameraTIFF(orientation int) []byte {
+	const entries = 3
+	dataOff := 8 + 2 + entries*12 + 4
+	gpsOff := dataOff
+	noteOff := gpsOff + len(gpsSecret)
+
+	b := []byte{'M', 'M', 0, '*'}
+	b = binary.BigEndian.AppendUint32(b, 8)
+	b = binary.BigEndian.AppendUint16(b, entries)
+	// Orientation, SHORT
+	b = binary.BigEndian.AppendUint16(b, tagOrientation)
+	b = binary.BigEndian.AppendUint16(b, 3)
+	b = binary.BigEndian.AppendUint32(b, 1)
+	b = binary.BigEndian.AppendUint16(b, uint16(orientation))
+	b = append(b, 0, 0)
+	// GPSInfo, LONG -> смещение
+	b = binary.BigEndian.AppendUint16(b, 0x8825)
+	b = binary.BigEndian.AppendUint16(b, 4)
+	b = binary.BigEndian.AppendUint32(b, 1)
+	b = binary.BigEndian.AppendUint32(b, uint32(gpsOff))
+	// MakerNote, UNDEFINED
+	b = binary.BigEndian.AppendUint16(b, 0x927C)
+	b = binary.BigEndian.AppendUint16(b, 7)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(makerNoteSecret)))
+	b = binary.BigEndian.AppendUint32(b, uint32(noteOff))
+	b = binary.BigEndian.AppendUint32(b, 0)
+	b = append(b, gpsSecret...)
+	return append(b, makerNoteSecret...)
+}
+
+func segment(m byte, payload []byte) []byte {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeSegment(w, m, payload)
+	w.Flush()
+	return buf.Bytes()
+}
+
+func chunk(typ string, data []byte) []byte {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeChunk(w, typ, data)
+	w.Flush()
+	return buf.Bytes()
+}
+
+// cameraJPEG — JPEG с EXIF (GPS, MakerNote), XMP, IPTC, комментарием и трейлером после EOI
+func cameraJPEG(t *testing.T, orientation int) []byte {
+	t.Helper()
+	var enc bytes.Buffer
+	if err := jpeg.Encode(&enc, testImage(), nil); err != nil {
+		t.Fatal(err)
+	}
+	raw := enc.Bytes()
+
+	var b bytes.Buffer
+	b.Write(raw[:2]) // SOI
+	b.Write(segment(markerAPP1, append(append([]byte{}, exifHeader...), cameraTIFF(orientation)...)))
+	b.Write(segment(markerAPP1, append([]byte("http://ns.adobe.com/xap/1.0/\x00"), xmpSecret...)))
+	b.Write(segment(0xED, []byte("Photoshop 3.0\x00IPTC")))
+	b.Write(segment(markerCOM, []byte("comment")))
+	b.Write(raw[2:])
+	b.Write(trailerSecret)
+	return b.Bytes()
+}
+
+// cameraPNG — PNG с tEXt, iTXt, eXIf и трейлером после IEND
+func cameraPNG(t *testing.T, orientation int) []byte {
+	t.Helper()
+	var enc bytes.Buffer
+	if err := png.Encode(&enc, testImage()); err != nil {
+		t.Fatal(err)
+	}
+	raw := enc.Bytes()
+	ihdrEnd := len(pngSignature) + 8 + 13 + 4
+
+	var b bytes.Buffer
+	b.Write(raw[:ihdrEnd])
+	b.Write(chunk("tEXt", append([]byte("Comment\x00"), textSecret...)))
+	b.Write(chunk("iTXt", append([]byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"), xmpSecret...)))
+	b.Write(chunk("eXIf", cameraTIFF(orientation)))
+	b.Write(raw[ihdrEnd:])
+	b.Write(trailerSecret)
+	return b.Bytes()
+}
+
+// run прогоняет in через фильтр; зависание — провал теста
+func run(t *testing.T, in []byte) ([]byte, Result, error) {
+	t.Helper()
+	type out struct {
+		data []byte
+		err  error
+	}
+	s := New(bytes.NewReader(in))
+	done := make(chan out, 1)
+	go func() {
+		data, err := io.ReadAll(s)
+		done <- out{data, err}
+	}()
+	select {
+	case o := <-done:
+		s.Close()
+		return o.data, s.Result(), o.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("sanitize hung")
+		return nil, Result{}, nil
+	}
+}
+
+func assertClean(t *testing.T, out []byte, secrets ...[]byte) {
+	t.Helper()
+	for _, sec := range secrets {
+		if bytes.Contains(out, sec) {
+			t.Errorf("output still contains %q", sec)
+		}
+	}
+}
+
+func assertDecodes(t *testing.T, out []byte) {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("output does not decode: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 32 || b.Dy() != 16 {
+		t.Fatalf("decoded %dx%d, want 32x16", b.Dx(), b.Dy())
+	}
+}
+
+func TestJPEG(t *testing.T) {
+	out, res, err := run(t, cameraJPEG(t, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Format != "jpeg" || res.Orientation != 6 {
+		t.Fatalf("result = %+v, want jpeg with orientation 6", res)
+	}
+	assertClean(t, out, gpsSecret, makerNoteSecret, xmpSecret, trailerSecret, []byte("IPTC"), []byte("comment"))
+	assertDecodes(t, out)
+
+	// ориентация осталась в минимальном EXIF
+	i := bytes.Index(out, exifHeader)
+	if i < 0 {
+		t.Fatal("orientation EXIF not written")
+	}
+	if o := exifOrientation(out[i+len(exifHeader):]); o != 6 {
+		t.Fatalf("orientation in output = %d, want 6", o)
+	}
+	if !bytes.HasSuffix(out, []byte{0xFF, markerEOI}) {
+		t.Fatal("output does not end with EOI")
+	}
+}
+
+func TestJPEGWithoutRotation(t *testing.T) {
+	out, res, err := run(t, cameraJPEG(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Orientation != 1 {
+		t.Fatalf("orientation = %d, want 1", res.Orientation)
+	}
+	// поворот не нужен — EXIF не пишем вовсе
+	if bytes.Contains(out, exifHeader) {
+		t.Fatal("unexpected EXIF in output")
+	}
+	assertDecodes(t, out)
+}
+
+func TestPNG(t *testing.T) {
+	out, res, err := run(t, cameraPNG(t, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Format != "png" || res.Orientation != 3 {
+		t.Fatalf("result = %+v, want png with orientation 3", res)
+	}
+	assertClean(t, out, gpsSecret, makerNoteSecret, xmpSecret, textSecret, trailerSecret,
+		[]byte("tEXt"), []byte("iTXt"))
+	assertDecodes(t, out)
+
+	i := bytes.Index(out, []byte("eXIf"))
+	if i < 0 {
+		t.Fatal("orientation eXIf not written")
+	}
+	if o := exifOrientation(out[i+4:]); o != 3 {
+		t.Fatalf("orientation in output = %d, want 3", o)
+	}
+	if !bytes.HasSuffix(out, chunk("IEND", nil)) {
+		t.Fatal("output does not end with IEND")
+	}
+}
+
+func TestPassThrough(t *testing.T) {
+	in := []byte("\x00\x00\x00\x18ftypmp42 not an image")
+	out, res, err := run(t, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Sanitized() || !bytes.Equal(out, in) {
+		t.Fatalf("non-image changed: sanitized=%v", res.Sanitized())
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	j := cameraJPEG(t, 6)
+	p := cameraPNG(t, 3)
+	sos := bytes.Index(j, []byte{0xFF, markerSOS})
+
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{"jpeg soi only", []byte{0xFF, markerSOI, 0xFF}},
+		{"jpeg cut in segment length", j[:5]},
+		{"jpeg cut in exif", j[:40]},
+		{"jpeg cut in scan", j[:sos+40]},
+		{"jpeg no eoi", j[:bytes.LastIndex(j, []byte{0xFF, markerEOI})]},
+		{"jpeg segment length < 2", []byte{0xFF, markerSOI, 0xFF, markerCOM, 0x00, 0x01}},
+		{"jpeg garbage instead of marker", []byte{0xFF, markerSOI, 0xFF, markerCOM, 0x00, 0x02, 0x12, 0x34}},
+		{"png signature only", pngSignature},
+		{"png cut in chunk header", p[:len(pngSignature)+4]},
+		{"png cut in chunk", p[:len(pngSignature)+20]},
+		{"png no iend", p[:bytes.LastIndex(p, []byte("IEND"))-4]},
+		{"png huge chunk", append(append([]byte{}, pngSignature...), 0xFF, 0xFF, 0xFF, 0xF0, 't', 'E', 'X', 't')},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := run(t, tt.in)
+			if !errors.Is(err, ErrMalformed) {
+				t.Fatalf("err = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
+
+// читатель бросил поток на середине — Close не должен зависнуть
+func TestCloseEarly(t *testing.T) {
+	s := New(bytes.NewReader(cameraJPEG(t, 6)))
+	buf := make([]byte, 16)
+	if _, err := s.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		s.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close hung")
+	}
+}
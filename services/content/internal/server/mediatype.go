@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// сколько байт смотрит http.DetectContentType
+const sniffLen = 512
+
+// если media.allowed_types не задан; 0 — только общий storage.max_upload_size
+var defaultAllowedTypes = map[string]int64{
+	"image/jpeg": 20 << 20,
+	"image/png":  20 << 20,
+	"image/webp": 20 << 20,
+	"image/gif":  10 << 20,
+	"video/mp4":  0,
+}
+
+func newAllowedTypes(cfg *cfgpkg.Config) map[string]int64 {
+	if len(cfg.Media.AllowedTypes) == 0 {
+		return defaultAllowedTypes
+	}
+	out := make(map[string]int64, len(cfg.Media.AllowedTypes))
+	for mime, limit := range cfg.Media.AllowedTypes {
+		out[strings.ToLower(strings.TrimSpace(mime))] = limit
+	}
+	return out
+}
+
+// tooLargeError — файл больше лимита: общего (mime пустой) или для своего типа
+type tooLargeError struct {
+	mime  string
+	limit int64
+}
+
+func (e *tooLargeError) Error() string {
+	if e.mime == "" {
+		return fmt.Sprintf("file exceeds max upload size of %d bytes", e.limit)
+	}
+	return fmt.Sprintf("%s files are limited to %d bytes", e.mime, e.limit)
+}
+
+// sniff определяет тип по самим байтам (mime от клиента не верим), сверяет с allow-list
+// и возвращает reader, который дальше читать вместо r: с ним же работает лимит типа
+func (s *Server) sniff(r io.Reader) (io.Reader, string, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, "", err
+	}
+	if len(head) == 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "empty file")
+	}
+
+	mime, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	limit, ok := s.allowedTypes[mime]
+	if !ok {
+		return nil, "", status.Errorf(codes.InvalidArgument, "unsupported media type %s (allowed: %s)",
+			mime, strings.Join(s.allowedList(), ", "))
+	}
+	if limit <= 0 || limit > s.maxUploadSize {
+		limit = s.maxUploadSize
+	}
+	return &limitReader{r: br, left: limit, err: &tooLargeError{mime: mime, limit: limit}}, mime, nil
+}
+
+func (s *Server) allowedList() []string {
+	out := make([]string, 0, len(s.allowedTypes))
+	for mime := range s.allowedTypes {
+		out = append(out, mime)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// limitReader отдаёт err, как только прочитано больше left байт
+type limitReader struct {
+	r    io.Reader
+	left int64
+	err  error
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return 0, l.err
+	}
+	return n, err
+}
@@ -1,87 +1,89 @@
 package server
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
+	"database/sql"
+	"errors"
 	"log/slog"
 	"path/filepath"
 	"time"
 
-	"github.com/confluentinc/confluent-kafka-go/kafka"
+	authpkg "github.com/mariapetrova3009/insta-backend/pkg/auth"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/events"
 	"github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
 	"github.com/mariapetrova3009/insta-backend/services/content/internal/storage"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/google/uuid"
 	commonpb "github.com/mariapetrova3009/insta-backend/proto/common"
 	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
+	evpb "github.com/mariapetrova3009/insta-backend/proto/events"
 )
 
+// лимит id в одном BatchGetPosts (страница ленты — максимум 100)
+const maxBatchPosts = 100
+
 type Server struct {
 	contentpb.UnimplementedContentServiceServer
 	log              *slog.Logger
 	repo             *repo.Repo
 	store            storage.Storage
-	prod             *kafka.Producer
 	topicPostCreated string
+	topicPostDeleted string
+	maxUploadSize    int64
+	allowedTypes     map[string]int64 // mime -> лимит размера
+	presignTTL       time.Duration
+	images           imageOptions
+	jwtSecret        []byte
+	variantJobs      chan variantJob
 }
 
-func New(log *slog.Logger, repo *repo.Repo, store storage.Storage, prod *kafka.Producer, topicPostCreated string) *Server {
-	return &Server{log: log, repo: repo, store: store, prod: prod, topicPostCreated: topicPostCreated}
-}
-
-func (s *Server) UploadMedia(ctx context.Context, in *contentpb.UploadMediaRequest) (*contentpb.UploadMediaResponse, error) {
-	// uniq id
-	id := uuid.New()
-	keyName := filepath.Join(id.String(), in.GetName())
-
-	res, err := s.store.Put(keyName, in.Data, in.Mime)
-	if err != nil {
-		s.log.Error("upload failed", "err", err)
-		return nil, fmt.Errorf("failed to store file: %w", err)
+func New(log *slog.Logger, cfg *cfgpkg.Config, repo *repo.Repo, store storage.Storage) *Server {
+	maxUpload := cfg.Storage.MaxUploadSize
+	if maxUpload <= 0 {
+		maxUpload = defaultMaxUploadSize
 	}
-
-	media := &repo.Media{
-		ID:        id,
-		Path:      res.Key, // путь в хранилище (может быть URL или просто key)
-		Mime:      in.GetMime(),
-		Size:      res.Size,
-		CreatedAt: time.Now().UTC(),
+	images := newImageOptions(cfg)
+	return &Server{
+		log:              log,
+		repo:             repo,
+		store:            store,
+		topicPostCreated: cfg.Kafka.Topics.PostCreated,
+		topicPostDeleted: cfg.Kafka.Topics.PostDeleted,
+		maxUploadSize:    maxUpload,
+		allowedTypes:     newAllowedTypes(cfg),
+		presignTTL:       cfg.Storage.PresignTTL,
+		images:           images,
+		variantJobs:      make(chan variantJob, images.queue),
+		jwtSecret:        []byte(cfg.JWT.Secret),
 	}
+}
 
-	if err := s.repo.CreateMedia(ctx, media); err != nil {
-		s.log.Error("create media failed", "err", err)
-		return nil, fmt.Errorf("failed to save metadata: %w", err)
+func (s *Server) UploadMedia(ctx context.Context, in *contentpb.UploadMediaRequest) (*contentpb.UploadMediaResponse, error) {
+	if int64(len(in.GetData())) > s.maxUploadSize {
+		return nil, status.Error(codes.InvalidArgument, (&tooLargeError{limit: s.maxUploadSize}).Error())
 	}
-
-	return &contentpb.UploadMediaResponse{
-		MediaPath: res.Key,
-		Name:      in.GetName(),
-		Mime:      in.GetMime(),
-	}, nil
+	return s.storeMedia(ctx, in.GetName(), in.GetMime(), bytes.NewReader(in.GetData()))
 }
 
 func (s *Server) CreatePost(ctx context.Context, in *contentpb.CreatePostRequest) (*contentpb.PostResponse, error) {
-
-	var authorID uuid.UUID
-	{
-		// ключ "user-id" замени на тот, что реально прокидывает твой gateway/JWT-мидлварь
-		md, _ := metadata.FromIncomingContext(ctx)
-		if vals := md.Get("user-id"); len(vals) > 0 {
-			if aid, err := uuid.Parse(vals[0]); err == nil {
-				authorID = aid
-			} else {
-				s.log.Warn("invalid user-id in metadata", "value", vals[0], "err", err)
-			}
-		}
-		if authorID == uuid.Nil {
-			s.log.Warn("author_id is empty; feed may not be able to attribute the post")
-
-		}
+	// автор — из JWT, как и в DeletePost: кто создал, тот и удаляет
+	authorID, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
 	}
 
-	mediaID := uuid.MustParse(filepath.Base(filepath.Dir(in.GetMediaPath())))
+	// путь медиа — <media_id>/<файл>, см. storeMedia
+	mediaID, err := uuid.Parse(filepath.Base(filepath.Dir(in.GetMediaPath())))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid media_path")
+	}
 	media := repo.Media{
 		ID: mediaID,
 	}
@@ -93,81 +95,175 @@ func (s *Server) CreatePost(ctx context.Context, in *contentpb.CreatePostRequest
 		Media:     media,
 		CreatedAt: time.Now().UTC(),
 	}
-	if err := s.repo.CreatePost(ctx, &p); err != nil {
-		return nil, err
+	// событие уходит в outbox в одной транзакции с постом, в Kafka его отправит relay
+	evt, err := outboxEvent(s.topicPostCreated, p.ID.String(), &evpb.PostCreated{
+		PostId:    p.ID.String(),
+		AuthorId:  p.AuthorID.String(),
+		CreatedAt: timestamppb.New(p.CreatedAt),
+	})
+	if err != nil {
+		s.log.Error("marshal event failed", "err", err)
+		return nil, status.Error(codes.Internal, "event error")
+	}
+	err = s.repo.CreatePost(ctx, &p, evt)
+	switch {
+	case errors.Is(err, repo.ErrMediaNotFound):
+		return nil, status.Error(codes.NotFound, "media not found")
+	case err != nil:
+		s.log.Error("create post failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
 	}
 
-	// TODO: Kafka
+	// p.Media заполнил repo: путь и mime сохранённого медиа
+	return &contentpb.PostResponse{Post: s.toPBPost(&p)}, nil
+}
 
-	// событие
-	evt := struct {
-		PostID      string `json:"post_id"`
-		AuthorID    string `json:"author_id,omitempty"`
-		Caption     string `json:"caption"`
-		MediaPath   string `json:"media_path"`
-		Mime        string `json:"mime"`
-		CreatedAtMs int64  `json:"created_at_ms"`
-	}{
-		PostID:      p.ID.String(),
-		AuthorID:    authorID.String(),
-		Caption:     p.Caption,
-		MediaPath:   in.GetMediaPath(),
-		Mime:        in.GetMime(),
-		CreatedAtMs: time.Now().UTC().UnixMilli(),
+// outboxEvent — событие для outbox: payload и заголовки из pkg/events
+func outboxEvent(topic, key string, msg proto.Message) (*repo.OutboxMessage, error) {
+	evt, err := events.Encode(msg)
+	if err != nil {
+		return nil, err
 	}
+	return &repo.OutboxMessage{
+		Topic:   topic,
+		Key:     []byte(key),
+		Payload: evt.Payload,
+		Headers: map[string]string{
+			events.HeaderSchema:      evt.Schema,
+			events.HeaderContentType: evt.ContentType,
+		},
+	}, nil
+}
 
-	payload, err := json.Marshal(evt)
+// DeletePost — автор удаляет свой пост (мягко, deleted_at); из лент его убирает feed по PostDeleted
+func (s *Server) DeletePost(ctx context.Context, in *contentpb.DeletePostRequest) (*commonpb.Empty, error) {
+	authorID, err := s.userIDFromAuth(ctx)
 	if err != nil {
-		s.log.Error("marshal event failed", "err", err)
-	} else {
-		key := []byte(p.ID.String())
-		topic := s.topicPostCreated
-
-		// отправка в Kafka
-		err = s.prod.Produce(&kafka.Message{
-			TopicPartition: kafka.TopicPartition{
-				Topic:     &topic,
-				Partition: kafka.PartitionAny,
-			},
-			Key:   key,
-			Value: payload,
-			Headers: []kafka.Header{
-				{Key: "schema", Value: []byte("content.post.created.v1")},
-				{Key: "content-type", Value: []byte("application/json")},
-			},
-		}, nil)
-
-		if err != nil {
-			s.log.Error("kafka produce failed", "err", err)
-		}
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	pid, err := uuid.Parse(in.GetPostId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid post_id")
 	}
 
-	//
+	evt, err := outboxEvent(s.topicPostDeleted, pid.String(), &evpb.PostDeleted{
+		PostId:    pid.String(),
+		AuthorId:  authorID.String(),
+		DeletedAt: timestamppb.Now(),
+	})
+	if err != nil {
+		s.log.Error("marshal event failed", "err", err)
+		return nil, status.Error(codes.Internal, "event error")
+	}
 
-	post := &commonpb.Post{
-		Id:        p.ID.String(),
-		Caption:   p.Caption,
-		MediaPath: in.GetMediaPath(),
-		Mime:      in.GetMime(),
+	err = s.repo.DeletePost(ctx, pid, authorID, evt)
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "post not found")
+	case errors.Is(err, repo.ErrNotAuthor):
+		return nil, status.Error(codes.PermissionDenied, "only the author can delete the post")
+	case err != nil:
+		s.log.Error("delete post failed", "post_id", pid, "err", err)
+		return nil, status.Error(codes.Internal, "db error")
 	}
-	return &contentpb.PostResponse{Post: post}, nil
+	// медиа остаётся за постом; файлы уберёт сборщик после media.gc.deleted_retention
+	return &commonpb.Empty{}, nil
 }
 
 func (s *Server) GetPost(ctx context.Context, in *contentpb.GetPostRequest) (*contentpb.PostResponse, error) {
 	pid, err := uuid.Parse(in.GetPostId())
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, "invalid post_id")
+	}
+	p, err := s.repo.GetPost(ctx, pid, s.callerID(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "post not found") // нет или удалён
 	}
-	p, err := s.repo.GetPost(ctx, pid)
 	if err != nil {
 		return nil, err
 	}
-	post := &commonpb.Post{
-		Id:        p.ID.String(),
-		Caption:   p.Caption,
-		MediaPath: p.Media.Path,
-		Mime:      p.Media.Mime,
+	return &contentpb.PostResponse{Post: s.toPBPost(p)}, nil
+
+}
+
+// BatchGetPosts — гидратация ленты: одна выборка на страницу вместо N вызовов GetPost
+func (s *Server) BatchGetPosts(ctx context.Context, in *contentpb.BatchGetPostsRequest) (*contentpb.BatchGetPostsResponse, error) {
+	if len(in.GetPostIds()) > maxBatchPosts {
+		return nil, status.Errorf(codes.InvalidArgument, "too many post_ids (max %d)", maxBatchPosts)
+	}
+	ids := make([]uuid.UUID, 0, len(in.GetPostIds()))
+	for _, raw := range in.GetPostIds() {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid post_id %q", raw)
+		}
+		ids = append(ids, id)
+	}
+
+	rows, err := s.repo.GetPosts(ctx, ids, s.callerID(ctx))
+	if err != nil {
+		s.log.Error("batch get posts failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	posts := make([]*commonpb.Post, 0, len(rows))
+	for i := range rows {
+		posts = append(posts, s.toPBPost(&rows[i]))
 	}
-	return &contentpb.PostResponse{Post: post}, nil
+	return &contentpb.BatchGetPostsResponse{Posts: posts}, nil
+}
+
+func (s *Server) toPBPost(p *repo.Post) *commonpb.Post {
+	return &commonpb.Post{
+		Id:            p.ID.String(),
+		AuthorId:      p.AuthorID.String(),
+		Caption:       p.Caption,
+		MediaPath:     p.Media.Path,
+		Mime:          p.Media.Mime,
+		LikesCount:    p.LikesCount,
+		CommentsCount: p.CommentsCount,
+		LikedByMe:     p.LikedByMe,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		MediaUrl:      s.mediaURL(p.Media.Path),
+		Variants:      s.toPBVariants(p.Variants),
+	}
+}
 
+// mediaURL — подписанная ссылка на файл; пустая, если подписать не вышло
+func (s *Server) mediaURL(path string) string {
+	if path == "" {
+		return ""
+	}
+	u, err := s.store.Presign(path, s.presignTTL)
+	if err != nil {
+		s.log.Warn("presign failed", "path", path, "err", err)
+		return ""
+	}
+	return u
+}
+
+// callerID читает "user-id", который gateway кладёт в metadata после JWTMiddleware;
+// uuid.Nil, если его нет или он битый. Подписи нет — только для того, что не требует
+// прав (liked_by_me); права проверяем по JWT (userIDFromAuth)
+func (s *Server) callerID(ctx context.Context) uuid.UUID {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get("user-id")
+	if len(vals) == 0 {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(vals[0])
+	if err != nil {
+		s.log.Warn("invalid user-id in metadata", "value", vals[0], "err", err)
+		return uuid.Nil
+	}
+	return id
+}
+
+// userIDFromAuth — id пользователя из JWT в metadata ("authorization")
+func (s *Server) userIDFromAuth(ctx context.Context) (uuid.UUID, error) {
+	sub, err := authpkg.UserIDFromIncoming(ctx, s.jwtSecret)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(sub)
 }
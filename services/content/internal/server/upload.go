@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/sanitize"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// если storage.max_upload_size не задан
+const defaultMaxUploadSize = 100 << 20
+
+var errBadMessage = errors.New("unexpected message in upload stream")
+
+// UploadMediaStream — загрузка больших файлов: сначала header, потом chunk'и.
+// Файл идёт в хранилище по мере чтения, целиком в памяти не лежит.
+func (s *Server) UploadMediaStream(stream contentpb.ContentService_UploadMediaStreamServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty upload stream")
+	}
+	if err != nil {
+		return err
+	}
+	hdr := first.GetHeader()
+	if hdr == nil {
+		return status.Error(codes.InvalidArgument, "first message must be header")
+	}
+
+	r := &chunkReader{stream: stream, max: s.maxUploadSize}
+	res, err := s.storeMedia(stream.Context(), hdr.GetName(), hdr.GetMime(), r)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(res)
+}
+
+// storeMedia — общая часть UploadMedia и UploadMediaStream: файл в хранилище + строка в media.
+// clientMime только для лога: тип определяем сами по содержимому.
+func (s *Server) storeMedia(ctx context.Context, name, clientMime string, r io.Reader) (*contentpb.UploadMediaResponse, error) {
+	// кто загрузил — из JWT: своё медиа потом можно поставить на аватарку
+	uploaderID, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+
+	// тип по первым байтам + allow-list и лимит размера для этого типа
+	r, mime, err := s.sniff(r)
+	if err != nil {
+		return nil, s.uploadError(err)
+	}
+	if clientMime != "" && clientMime != mime {
+		s.log.Debug("client mime differs from content", "client", clientMime, "sniffed", mime)
+	}
+
+	// uniq id
+	id := uuid.New()
+	keyName := filepath.Join(id.String(), name)
+
+	// метаданные (EXIF с GPS, XMP, IPTC) вырезаем потоком до записи в хранилище
+	san := sanitize.New(r)
+	defer san.Close()
+	r = san
+
+	// картинку по дороге в хранилище копируем во временный файл — из него потом режем варианты
+	var orig *os.File
+	if len(s.images.variants) > 0 && strings.HasPrefix(mime, "image/") {
+		tmp, err := os.CreateTemp("", "media-*")
+		if err != nil {
+			s.log.Error("create temp failed", "err", err)
+			return nil, status.Error(codes.Internal, "failed to store file")
+		}
+		defer func() {
+			if orig != nil { // не ушёл в нарезку
+				removeTemp(orig)
+			}
+		}()
+		orig = tmp
+		r = io.TeeReader(r, tmp)
+	}
+
+	// хэш того, что реально ляжет в хранилище (после очистки) — ключ дедупликации
+	hash := sha256.New()
+	r = io.TeeReader(r, hash)
+
+	res, err := s.store.Put(keyName, r, mime)
+	san.Close()
+	if err != nil {
+		return nil, s.uploadError(err)
+	}
+
+	now := time.Now().UTC()
+	clean := san.Result()
+	media := &repo.Media{
+		ID:         id,
+		Path:       res.Key, // путь в хранилище (может быть URL или просто key)
+		Mime:       mime,
+		Size:       res.Size,
+		CreatedAt:  now,
+		SHA256:     hex.EncodeToString(hash.Sum(nil)),
+		UploadedAt: now,
+	}
+	if clean.Sanitized() {
+		media.SanitizedAt = sql.NullTime{Time: now, Valid: true}
+		if len(clean.Removed) > 0 {
+			s.log.Debug("media metadata stripped", "media_id", id, "removed", clean.Removed)
+		}
+	}
+
+	created, err := s.repo.SaveMedia(ctx, media, uploaderID)
+	if err != nil {
+		s.log.Error("create media failed", "err", err)
+		s.deleteBlob(res.Key)
+		return nil, fmt.Errorf("failed to save metadata: %w", err)
+	}
+
+	switch {
+	case !created:
+		// такое содержимое уже лежит в хранилище: отдаём его, свою копию убираем
+		s.log.Debug("media deduplicated", "media_id", media.ID, "sha256", media.SHA256)
+		s.deleteBlob(res.Key)
+	case orig != nil:
+		// нарезка в фоне (RunVariants): в запросе не держим память и время на неё
+		if s.enqueueVariants(id, orig, clean.Orientation) {
+			orig = nil
+		}
+	}
+
+	return &contentpb.UploadMediaResponse{
+		MediaPath: media.Path,
+		Name:      name,
+		Mime:      media.Mime,
+	}, nil
+}
+
+// deleteBlob — уборка файла, ошибка только в лог: хвосты подберёт сборщик
+func (s *Server) deleteBlob(key string) {
+	if err := s.store.Delete(key); err != nil {
+		s.log.Warn("delete blob failed", "key", key, "err", err)
+	}
+}
+
+// uploadError переводит ошибку чтения/записи файла в gRPC-статус с понятной причиной
+func (s *Server) uploadError(err error) error {
+	var tooLarge *tooLargeError
+	switch {
+	case errors.As(err, &tooLarge):
+		return status.Error(codes.InvalidArgument, tooLarge.Error())
+	case errors.Is(err, errBadMessage):
+		return status.Error(codes.InvalidArgument, "expected chunk after header")
+	case errors.Is(err, sanitize.ErrMalformed):
+		return status.Error(codes.InvalidArgument, "malformed image")
+	}
+	if st, ok := status.FromError(err); ok {
+		if errors.Unwrap(err) == nil {
+			return st.Err() // статус как есть: наш из sniff или прямо из Recv
+		}
+		// стрим оборвался посреди записи (клиент отменил, дедлайн), хранилище обернуло ошибку
+		return status.Error(st.Code(), "upload stream aborted")
+	}
+	s.log.Error("upload failed", "err", err)
+	return fmt.Errorf("failed to store file: %w", err)
+}
+
+// chunkReader превращает стрим chunk'ов в io.Reader и следит за лимитом размера
+type chunkReader struct {
+	stream contentpb.ContentService_UploadMediaStreamServer
+	buf    []byte
+	n, max int64
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		msg, err := c.stream.Recv()
+		if err != nil {
+			return 0, err // io.EOF — клиент закрыл стрим, файл кончился
+		}
+		if msg.GetHeader() != nil {
+			return 0, errBadMessage
+		}
+		c.buf = msg.GetChunk()
+		c.n += int64(len(c.buf))
+		if c.n > c.max {
+			return 0, &tooLargeError{limit: c.max}
+		}
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
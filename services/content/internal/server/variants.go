@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/google/uuid"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	commonpb "github.com/mariapetrova3009/insta-backend/proto/common"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/imgproc"
+	"github.com/mariapetrova3009/insta-backend/services/content/internal/repo"
+)
+
+const (
+	defaultJPEGQuality    = 85
+	defaultMaxPixels      = 50_000_000
+	defaultVariantWorkers = 2
+	defaultVariantQueue   = 64
+)
+
+// imageOptions — настройки нарезки из config.Media
+type imageOptions struct {
+	variants    []imgproc.Spec
+	jpegQuality int
+	maxPixels   int64
+	workers     int
+	queue       int
+}
+
+// variantJob — оригинал во временном файле, ждёт нарезки; файл удаляет воркер
+type variantJob struct {
+	mediaID     uuid.UUID
+	file        *os.File
+	orientation int
+}
+
+func newImageOptions(cfg *cfgpkg.Config) imageOptions {
+	o := imageOptions{
+		jpegQuality: cfg.Media.JPEGQuality,
+		maxPixels:   cfg.Media.MaxPixels,
+	}
+	if o.jpegQuality <= 0 || o.jpegQuality > 100 {
+		o.jpegQuality = defaultJPEGQuality
+	}
+	if o.maxPixels <= 0 {
+		o.maxPixels = defaultMaxPixels
+	}
+	o.workers = cfg.Media.VariantWorkers
+	if o.workers <= 0 {
+		o.workers = defaultVariantWorkers
+	}
+	o.queue = cfg.Media.VariantQueue
+	if o.queue <= 0 {
+		o.queue = defaultVariantQueue
+	}
+	for _, v := range cfg.Media.Variants {
+		if v.Name == "" || v.Width <= 0 {
+			continue
+		}
+		o.variants = append(o.variants, imgproc.Spec{Name: v.Name, Width: v.Width, Square: v.Square})
+	}
+	return o
+}
+
+// enqueueVariants отдаёт оригинал фоновой нарезке; false — очередь полна,
+// файл остаётся за вызывающим
+func (s *Server) enqueueVariants(mediaID uuid.UUID, file *os.File, orientation int) bool {
+	select {
+	case s.variantJobs <- variantJob{mediaID: mediaID, file: file, orientation: orientation}:
+		return true
+	default:
+		s.log.Warn("variant queue full, media left without variants", "media_id", mediaID)
+		return false
+	}
+}
+
+// RunVariants — images.workers воркеров нарезки до отмены ctx;
+// недоделанные задачи при остановке отбрасываются (медиа остаётся без вариантов)
+func (s *Server) RunVariants(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range s.images.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.variantJobs:
+					// варианты не обязательны: без них клиент просто получит оригинал
+					if err := s.makeVariants(ctx, job.mediaID, job.file, job.orientation); err != nil {
+						s.log.Warn("image variants failed", "media_id", job.mediaID, "err", err)
+					}
+					removeTemp(job.file)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for {
+		select {
+		case job := <-s.variantJobs:
+			removeTemp(job.file)
+		default:
+			return
+		}
+	}
+}
+
+func removeTemp(f *os.File) {
+	f.Close()
+	os.Remove(f.Name())
+}
+
+// makeVariants нарезает оригинал (копия во временном файле) и кладёт варианты
+// в хранилище под variants/<media_id>/<name>.<ext>; orientation — EXIF-тег оригинала
+func (s *Server) makeVariants(ctx context.Context, mediaID uuid.UUID, src *os.File, orientation int) error {
+	if _, err := src.Seek(0, 0); err != nil {
+		return err
+	}
+	img, format, err := imgproc.Decode(src, s.images.maxPixels)
+	if err != nil {
+		return err
+	}
+	img = imgproc.Orient(img, orientation)
+	results, err := imgproc.Variants(img, format, s.images.variants, s.images.jpegQuality)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]repo.Variant, 0, len(results))
+	for _, v := range results {
+		key := path.Join("variants", mediaID.String(), v.Name+v.Ext)
+		res, err := s.store.Put(key, bytes.NewReader(v.Data), v.Mime)
+		if err != nil {
+			s.deleteVariants(rows)
+			return fmt.Errorf("store variant %s: %w", v.Name, err)
+		}
+		rows = append(rows, repo.Variant{
+			Name:   v.Name,
+			Path:   res.Key,
+			Mime:   v.Mime,
+			Width:  v.Width,
+			Height: v.Height,
+			Size:   res.Size,
+		})
+	}
+
+	if err := s.repo.CreateVariants(ctx, mediaID, rows); err != nil {
+		s.deleteVariants(rows)
+		return fmt.Errorf("save variants: %w", err)
+	}
+	return nil
+}
+
+// deleteVariants — уборка уже загруженных файлов, если нарезка не удалась
+func (s *Server) deleteVariants(rows []repo.Variant) {
+	for _, v := range rows {
+		s.deleteBlob(v.Path)
+	}
+}
+
+func (s *Server) toPBVariants(vs []repo.Variant) []*commonpb.MediaVariant {
+	if len(vs) == 0 {
+		return nil
+	}
+	out := make([]*commonpb.MediaVariant, 0, len(vs))
+	for _, v := range vs {
+		out = append(out, &commonpb.MediaVariant{
+			Name:   v.Name,
+			Url:    s.mediaURL(v.Path),
+			Width:  int32(v.Width),
+			Height: int32(v.Height),
+			Mime:   v.Mime,
+		})
+	}
+	return out
+}
@@ -2,92 +2,153 @@ package storage
 
 import (
 	"fmt"           // форматирование ошибок
+	"io"            // потоковая запись
+	"io/fs"         // обход каталогов
 	"net/url"       // сборка корректного file:// URL
 	"os"            // файловые операции
 	"path/filepath" // нормализация путей и склейка
 	"strings"       // проверки префиксов
-	"time"          // TTL для Presign (пока не используем)
+	"time"          // TTL для Presign
 )
 
-type LocalFS struct{ root string }
+type LocalFS struct {
+	root   string
+	signer *URLSigner // nil — Presign отдаёт file:// (только для отладки)
+}
 
-func NewLocalFS(root string) *LocalFS { return &LocalFS{root: root} }
+func NewLocalFS(root string, signer *URLSigner) *LocalFS {
+	return &LocalFS{root: root, signer: signer}
+}
 
-func (s *LocalFS) Put(name string, data []byte, _ string) (PutResult, error) {
+func (s *LocalFS) Put(name string, r io.Reader, _ string) (PutResult, error) {
 	// check path
 	clean := filepath.Clean(name)
 	if clean == "." || clean == "" {
 		return PutResult{}, fmt.Errorf("empty name")
 	}
-	// make abs path
-	full := filepath.Join(s.root, clean)
-
-	// compare abs paths
-	absRoot, err := filepath.Abs(s.root)
+	// make abs path inside root
+	absFull, err := s.resolve(clean)
 	if err != nil {
-		return PutResult{}, fmt.Errorf("abs(root): %w", err)
-	}
-	absFull, err := filepath.Abs(full)
-	if err != nil {
-		return PutResult{}, fmt.Errorf("abs(full): %w", err)
-	}
-
-	sep := string(os.PathSeparator)
-	if !strings.HasPrefix(absFull+sep, absRoot+sep) {
-		return PutResult{}, fmt.Errorf("path escapes root: %q", clean)
+		return PutResult{}, err
 	}
 
 	// make dir and write file
 	if err := os.MkdirAll(filepath.Dir(absFull), 0o755); err != nil {
 		return PutResult{}, fmt.Errorf("mkdir: %w", err)
 	}
-	if err := os.WriteFile(absFull, data, 0o644); err != nil {
-		return PutResult{}, fmt.Errorf("write: %w", err)
+	// пишем во временный файл рядом и переименовываем:
+	// оборванная загрузка не оставит полфайла под нужным именем
+	tmp, err := os.CreateTemp(filepath.Dir(absFull), ".upload-*")
+	if err != nil {
+		return PutResult{}, fmt.Errorf("create temp: %w", err)
 	}
-
-	// calculate size
-	var size int64
-	if fi, err := os.Stat(absFull); err == nil {
-		size = fi.Size()
+	size, err := io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), absFull)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return PutResult{}, fmt.Errorf("write: %w", err)
 	}
 
 	return PutResult{Key: clean, Size: size}, nil
 }
 
 func (s *LocalFS) Delete(key string) error {
+	absFull, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	// delete file
+	if err := os.Remove(absFull); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
+}
+
+// Open открывает файл для отдачи по HTTP; вызывающий закрывает его сам
+func (s *LocalFS) Open(key string) (*os.File, error) {
+	absFull, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(absFull)
+}
+
+// resolve: абсолютный путь ключа внутри root; выход за root — ошибка
+func (s *LocalFS) resolve(key string) (string, error) {
 	clean := filepath.Clean(key)
 	full := filepath.Join(s.root, clean)
 
-	// check path
 	absRoot, err := filepath.Abs(s.root)
 	if err != nil {
-		return fmt.Errorf("abs(root): %w", err)
+		return "", fmt.Errorf("abs(root): %w", err)
 	}
 	absFull, err := filepath.Abs(full)
 	if err != nil {
-		return fmt.Errorf("abs(full): %w", err)
+		return "", fmt.Errorf("abs(full): %w", err)
 	}
 	sep := string(os.PathSeparator)
 	if !strings.HasPrefix(absFull+sep, absRoot+sep) {
-		return fmt.Errorf("path escapes root: %q", clean)
+		return "", fmt.Errorf("path escapes root: %q", clean)
 	}
+	return absFull, nil
+}
 
-	// delete file
-	if err := os.Remove(absFull); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("remove: %w", err)
+// Presign returns link to download: подписанный URL для GET /media/{key},
+// без signer — file:// (только для отладки)
+func (s *LocalFS) Presign(key string, ttl time.Duration) (string, error) {
+	clean := filepath.ToSlash(filepath.Clean(key))
+	if s.signer == nil {
+		abs := filepath.Join(s.root, clean)
+		path := filepath.ToSlash(abs)
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		u := url.URL{Scheme: "file", Path: path}
+		return u.String(), nil
 	}
-	return nil
-}
 
-// Presign returns link to download
-func (s *LocalFS) Presign(key string, _ time.Duration) (string, error) {
-	clean := filepath.Clean(key)
-	abs := filepath.Join(s.root, clean)
+	if ttl <= 0 {
+		ttl = defaultPresignTTL
+	}
+	// срок округляем вверх до границы окна ttl: в пределах окна ссылка одна и та же,
+	// и клиенты/прокси могут её кэшировать; живёт она от ttl до 2*ttl
+	exp := time.Now().Add(ttl).Truncate(ttl).Add(ttl)
+	return s.signer.Sign(clean, exp), nil
+}
 
-	path := filepath.ToSlash(abs)
-	if !strings.HasPrefix(path, "/") {
-		path = "/" + path
+// Walk обходит все файлы под root; key — тот же, что вернул Put
+func (s *LocalFS) Walk(fn func(key string, modTime time.Time) error) error {
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil // удалили во время обхода
+			}
+			return err
+		}
+		key, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		return fn(key, info.ModTime())
+	})
+	if os.IsNotExist(err) {
+		return nil // ещё ничего не загружали
 	}
-	u := url.URL{Scheme: "file", Path: path}
-	return u.String(), nil
+	return err
 }
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// размер части multipart-загрузки: размер файла заранее неизвестен (стрим),
+// столько буферизуется в памяти на одну загрузку; минимум у S3 — 5 МБ
+const s3PartSize = 8 << 20
+
+// S3Options — всё из config.Storage, что нужно S3-бэкенду
+type S3Options struct {
+	Endpoint   string // host:port или полный URL (http:// — без TLS)
+	Region     string
+	Bucket     string
+	AccessKey  string
+	SecretKey  string
+	PresignTTL time.Duration
+}
+
+// S3 — хранилище в S3-совместимом бакете (AWS, MinIO и т.п.)
+type S3 struct {
+	client     *minio.Client
+	bucket     string
+	presignTTL time.Duration
+}
+
+func NewS3(opts S3Options) (*S3, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3: bucket is required")
+	}
+	host, secure, err := parseEndpoint(opts.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	region := opts.Region
+	if region == "" {
+		// без региона minio-go ходит за GetBucketLocation перед каждым новым бакетом
+		region = "us-east-1"
+	}
+
+	client, err := minio.New(host, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: secure,
+		Region: region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3: client: %w", err)
+	}
+
+	ttl := opts.PresignTTL
+	if ttl <= 0 {
+		ttl = defaultPresignTTL
+	}
+	return &S3{client: client, bucket: opts.Bucket, presignTTL: ttl}, nil
+}
+
+func (s *S3) Put(name string, r io.Reader, mime string) (PutResult, error) {
+	key, err := objectKey(name)
+	if err != nil {
+		return PutResult{}, err
+	}
+
+	info, err := s.client.PutObject(context.Background(), s.bucket, key, r, -1, minio.PutObjectOptions{
+		ContentType: mime,
+		PartSize:    s3PartSize,
+	})
+	if err != nil {
+		return PutResult{}, fmt.Errorf("s3 put: %w", err)
+	}
+	return PutResult{Key: key, Size: info.Size}, nil
+}
+
+func (s *S3) Delete(key string) error {
+	k, err := objectKey(key)
+	if err != nil {
+		return err
+	}
+	// S3 отвечает 204 и на отсутствующий объект — как os.IsNotExist в LocalFS
+	if err := s.client.RemoveObject(context.Background(), s.bucket, k, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("s3 delete: %w", err)
+	}
+	return nil
+}
+
+// Presign — подписанная GET-ссылка на объект; ttl <= 0 — storage.presign_ttl
+func (s *S3) Presign(key string, ttl time.Duration) (string, error) {
+	k, err := objectKey(key)
+	if err != nil {
+		return "", err
+	}
+	if ttl <= 0 {
+		ttl = s.presignTTL
+	}
+	u, err := s.client.PresignedGetObject(context.Background(), s.bucket, k, ttl, nil)
+	if err != nil {
+		return "", fmt.Errorf("s3 presign: %w", err)
+	}
+	return u.String(), nil
+}
+
+// Walk перечисляет объекты бакета (ListObjectsV2, постранично); key — тот же, что вернул Put
+func (s *S3) Walk(fn func(key string, modTime time.Time) error) error {
+	// отмена останавливает листинг, если fn прервала обход
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if obj.Err != nil {
+			return fmt.Errorf("s3 list: %w", obj.Err)
+		}
+		if err := fn(obj.Key, obj.LastModified); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectKey: ключи в S3 всегда через "/", без ведущего слэша и выходов наверх
+func objectKey(name string) (string, error) {
+	key := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	if key == "" {
+		return "", fmt.Errorf("empty name")
+	}
+	return key, nil
+}
+
+// parseEndpoint: "https://host", "http://host:9000" или просто "host:9000" (TLS)
+func parseEndpoint(endpoint string) (host string, secure bool, err error) {
+	if endpoint == "" {
+		return "", false, fmt.Errorf("s3: endpoint is required")
+	}
+	if !strings.Contains(endpoint, "://") {
+		return endpoint, true, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false, fmt.Errorf("s3: bad endpoint: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		return u.Host, true, nil
+	case "http":
+		return u.Host, false, nil
+	default:
+		return "", false, fmt.Errorf("s3: unsupported endpoint scheme %q", u.Scheme)
+	}
+}
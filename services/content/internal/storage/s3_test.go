@@ -0,0 +1,458 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testBucket    = "insta-media"
+	testAccessKey = "test-access"
+	testSecretKey = "test-secret"
+)
+
+// fakeS3 — минимальный S3-совместимый сервер в памяти: PUT/GET/DELETE объектов,
+// multipart upload и presigned GET. Подпись целиком не проверяет, только ключ доступа и срок.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte         // bucket/key -> data
+	mimes   map[string]string         // bucket/key -> Content-Type
+	uploads map[string]map[int][]byte // uploadId -> partNumber -> data
+	nextID  int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+	f := &fakeS3{
+		objects: make(map[string][]byte),
+		mimes:   make(map[string]string),
+		uploads: make(map[string]map[int][]byte),
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.authorized(r) {
+		s3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	q := r.URL.Query()
+	obj := strings.TrimPrefix(r.URL.Path, "/") // path-style: bucket/key
+	if !strings.HasPrefix(obj, testBucket+"/") {
+		s3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		f.nextID++
+		id := fmt.Sprintf("upload-%d", f.nextID)
+		f.uploads[id] = make(map[int][]byte)
+		f.mimes[obj] = r.Header.Get("Content-Type")
+		writeXML(w, fmt.Sprintf(`<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`,
+			testBucket, strings.TrimPrefix(obj, testBucket+"/"), id))
+
+	case r.Method == http.MethodPut && q.Has("uploadId"):
+		parts, ok := f.uploads[q.Get("uploadId")]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		data, err := readBody(r)
+		if err != nil {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		parts[n] = data
+		w.Header().Set("ETag", etag(data))
+
+	case r.Method == http.MethodPost && q.Has("uploadId"):
+		parts, ok := f.uploads[q.Get("uploadId")]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		nums := make([]int, 0, len(parts))
+		for n := range parts {
+			nums = append(nums, n)
+		}
+		sort.Ints(nums)
+		var buf bytes.Buffer
+		for _, n := range nums {
+			buf.Write(parts[n])
+		}
+		f.objects[obj] = buf.Bytes()
+		delete(f.uploads, q.Get("uploadId"))
+		writeXML(w, fmt.Sprintf(`<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><ETag>%s</ETag></CompleteMultipartUploadResult>`,
+			testBucket, strings.TrimPrefix(obj, testBucket+"/"), etag(buf.Bytes())))
+
+	case r.Method == http.MethodDelete && q.Has("uploadId"):
+		delete(f.uploads, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodPut:
+		data, err := readBody(r)
+		if err != nil {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[obj] = data
+		f.mimes[obj] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", etag(data))
+
+	case r.Method == http.MethodGet && q.Get("list-type") == "2":
+		f.writeList(w, q.Get("prefix"))
+
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[obj]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", f.mimes[obj])
+		_, _ = w.Write(data)
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, obj)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		s3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+// authorized: подписанный заголовок или presigned query с нашим ключом и живым сроком
+func (f *fakeS3) authorized(r *http.Request) bool {
+	q := r.URL.Query()
+	if q.Has("X-Amz-Signature") {
+		if !strings.HasPrefix(q.Get("X-Amz-Credential"), testAccessKey+"/") {
+			return false
+		}
+		signed, err := time.Parse("20060102T150405Z", q.Get("X-Amz-Date"))
+		if err != nil {
+			return false
+		}
+		secs, err := strconv.Atoi(q.Get("X-Amz-Expires"))
+		if err != nil {
+			return false
+		}
+		return time.Now().Before(signed.Add(time.Duration(secs) * time.Second))
+	}
+	return strings.Contains(r.Header.Get("Authorization"), "Credential="+testAccessKey+"/")
+}
+
+// writeList — ListObjectsV2 одной страницей; вызывается под f.mu
+func (f *fakeS3) writeList(w http.ResponseWriter, prefix string) {
+	keys := make([]string, 0, len(f.objects))
+	for obj := range f.objects {
+		key := strings.TrimPrefix(obj, testBucket+"/")
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<ListBucketResult><Name>%s</Name><Prefix>%s</Prefix><KeyCount>%d</KeyCount><IsTruncated>false</IsTruncated>`,
+		testBucket, prefix, len(keys))
+	for _, key := range keys {
+		data := f.objects[testBucket+"/"+key]
+		fmt.Fprintf(&b, `<Contents><Key>%s</Key><LastModified>2024-01-02T03:04:05.000Z</LastModified><ETag>%s</ETag><Size>%d</Size></Contents>`,
+			key, etag(data), len(data))
+	}
+	b.WriteString(`</ListBucketResult>`)
+	writeXML(w, b.String())
+}
+
+func (f *fakeS3) object(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[testBucket+"/"+key]
+	return data, ok
+}
+
+func (f *fakeS3) pendingUploads() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.uploads)
+}
+
+// readBody снимает aws-chunked обёртку, которую minio-go использует без TLS
+func readBody(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	br := bufio.NewReader(r.Body)
+	var out bytes.Buffer
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return out.Bytes(), nil
+		}
+		if _, err := io.CopyN(&out, br, size); err != nil {
+			return nil, err
+		}
+		if _, err := br.Discard(2); err != nil { // \r\n после данных
+			return nil, err
+		}
+	}
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func writeXML(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/xml")
+	_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>`+body)
+}
+
+func s3Error(w http.ResponseWriter, code int, s3code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, s3code, s3code)
+}
+
+func newTestS3(t *testing.T, endpoint, accessKey string) *S3 {
+	t.Helper()
+	s, err := NewS3(S3Options{
+		Endpoint:   endpoint,
+		Bucket:     testBucket,
+		AccessKey:  accessKey,
+		SecretKey:  testSecretKey,
+		PresignTTL: 10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	return s
+}
+
+func TestS3PutPresignDelete(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	s := newTestS3(t, srv.URL, testAccessKey)
+
+	data := []byte("not really a jpeg")
+	res, err := s.Put("0b1c/photo.jpg", bytes.NewReader(data), "image/jpeg")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if res.Key != "0b1c/photo.jpg" || res.Size != int64(len(data)) {
+		t.Fatalf("Put result = %+v", res)
+	}
+	if got, _ := fake.object("0b1c/photo.jpg"); !bytes.Equal(got, data) {
+		t.Fatalf("stored %q, want %q", got, data)
+	}
+
+	link, err := s.Presign(res.Key, 0)
+	if err != nil {
+		t.Fatalf("Presign: %v", err)
+	}
+	resp, err := http.Get(link)
+	if err != nil {
+		t.Fatalf("GET presigned: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !bytes.Equal(body, data) {
+		t.Fatalf("GET presigned = %d %q", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "image/jpeg" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	if err := s.Delete(res.Key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.object(res.Key); ok {
+		t.Fatal("object still exists after Delete")
+	}
+	// повторное удаление — не ошибка
+	if err := s.Delete(res.Key); err != nil {
+		t.Fatalf("second Delete: %v", err)
+	}
+}
+
+func TestS3Walk(t *testing.T) {
+	_, srv := newFakeS3(t)
+	s := newTestS3(t, srv.URL, testAccessKey)
+
+	want := []string{"0b1c/photo.jpg", "0b1c/photo_320.jpg", "9f2e/clip.mp4"}
+	for _, key := range want {
+		if _, err := s.Put(key, strings.NewReader(key), "application/octet-stream"); err != nil {
+			t.Fatalf("Put %s: %v", key, err)
+		}
+	}
+
+	var got []string
+	err := s.Walk(func(key string, modTime time.Time) error {
+		if modTime.IsZero() {
+			t.Errorf("%s: zero modTime", key)
+		}
+		got = append(got, key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Walk keys = %v, want %v", got, want)
+	}
+
+	// ошибка из fn прерывает обход и возвращается как есть
+	stop := errors.New("stop")
+	n := 0
+	err = s.Walk(func(string, time.Time) error {
+		n++
+		return stop
+	})
+	if !errors.Is(err, stop) || n != 1 {
+		t.Fatalf("Walk with stop = %v after %d calls", err, n)
+	}
+}
+
+func TestS3PutMultipart(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	s := newTestS3(t, srv.URL, testAccessKey)
+
+	// больше двух частей: последняя неполная
+	data := bytes.Repeat([]byte("0123456789abcdef"), (2*s3PartSize+12345)/16)
+	res, err := s.Put("big/video.mp4", io.MultiReader(bytes.NewReader(data)), "video/mp4")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if res.Size != int64(len(data)) {
+		t.Fatalf("Size = %d, want %d", res.Size, len(data))
+	}
+	if got, _ := fake.object("big/video.mp4"); !bytes.Equal(got, data) {
+		t.Fatalf("stored %d bytes, want %d", len(got), len(data))
+	}
+	if n := fake.pendingUploads(); n != 0 {
+		t.Fatalf("%d multipart uploads left open", n)
+	}
+}
+
+func TestS3PutReaderError(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	s := newTestS3(t, srv.URL, testAccessKey)
+
+	boom := errors.New("client went away")
+	r := io.MultiReader(bytes.NewReader(make([]byte, s3PartSize+1)), &errReader{err: boom})
+	if _, err := s.Put("broken/file.bin", r, "application/octet-stream"); !errors.Is(err, boom) {
+		t.Fatalf("Put err = %v, want %v", err, boom)
+	}
+	if _, ok := fake.object("broken/file.bin"); ok {
+		t.Fatal("partial object was stored")
+	}
+	if n := fake.pendingUploads(); n != 0 {
+		t.Fatalf("%d multipart uploads left open", n)
+	}
+}
+
+func TestS3PresignTTL(t *testing.T) {
+	_, srv := newFakeS3(t)
+	s := newTestS3(t, srv.URL, testAccessKey)
+
+	for _, tc := range []struct {
+		ttl  time.Duration
+		want string
+	}{
+		{0, "600"}, // PresignTTL из опций
+		{time.Minute, "60"},
+	} {
+		link, err := s.Presign("a/b.jpg", tc.ttl)
+		if err != nil {
+			t.Fatalf("Presign(%v): %v", tc.ttl, err)
+		}
+		u, err := url.Parse(link)
+		if err != nil {
+			t.Fatalf("parse %q: %v", link, err)
+		}
+		if got := u.Query().Get("X-Amz-Expires"); got != tc.want {
+			t.Errorf("Presign(%v) X-Amz-Expires = %q, want %q", tc.ttl, got, tc.want)
+		}
+		if u.Path != "/"+testBucket+"/a/b.jpg" {
+			t.Errorf("Presign(%v) path = %q", tc.ttl, u.Path)
+		}
+	}
+}
+
+func TestS3BadCredentials(t *testing.T) {
+	_, srv := newFakeS3(t)
+	s := newTestS3(t, srv.URL, "someone-else")
+
+	if _, err := s.Put("x/y.jpg", strings.NewReader("data"), "image/jpeg"); err == nil {
+		t.Fatal("Put with wrong access key succeeded")
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "id/photo.jpg", want: "id/photo.jpg"},
+		{in: "/id/photo.jpg", want: "id/photo.jpg"},
+		{in: "id/../../etc/passwd", want: "etc/passwd"},
+		{in: "", wantErr: true},
+		{in: "/", wantErr: true},
+	} {
+		got, err := objectKey(tc.in)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("objectKey(%q) = %q, %v", tc.in, got, err)
+		}
+	}
+}
+
+func TestParseEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		host   string
+		secure bool
+	}{
+		{"s3.amazonaws.com", "s3.amazonaws.com", true},
+		{"https://s3.example.com", "s3.example.com", true},
+		{"http://localhost:9000", "localhost:9000", false},
+	} {
+		host, secure, err := parseEndpoint(tc.in)
+		if err != nil || host != tc.host || secure != tc.secure {
+			t.Errorf("parseEndpoint(%q) = %q, %v, %v", tc.in, host, secure, err)
+		}
+	}
+	if _, _, err := parseEndpoint("ftp://x"); err == nil {
+		t.Error("parseEndpoint(ftp://x) succeeded")
+	}
+}
+
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadToken     = errors.New("bad media token")
+	ErrExpiredToken = errors.New("media token expired")
+)
+
+// URLSigner — ссылки на медиа вида <base>/<key>?token=<exp>.<sig>,
+// sig = HMAC-SHA256(key "\n" exp). Проверяет их HTTP-ручка content при отдаче файла.
+type URLSigner struct {
+	base   string // например "/media" или "https://cdn.example.com/media"
+	secret []byte
+}
+
+func NewURLSigner(base string, secret []byte) *URLSigner {
+	return &URLSigner{base: strings.TrimRight(base, "/"), secret: secret}
+}
+
+// Sign — готовая ссылка на key, действительная до exp
+func (s *URLSigner) Sign(key string, exp time.Time) string {
+	e := strconv.FormatInt(exp.Unix(), 10)
+	return s.base + "/" + escapeKey(key) + "?token=" + e + "." + s.sig(key, e)
+}
+
+// Verify проверяет token для key и возвращает срок его действия
+func (s *URLSigner) Verify(key, token string, now time.Time) (time.Time, error) {
+	e, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return time.Time{}, ErrBadToken
+	}
+	unix, err := strconv.ParseInt(e, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrBadToken
+	}
+	if !hmac.Equal([]byte(sig), []byte(s.sig(key, e))) {
+		return time.Time{}, ErrBadToken
+	}
+	exp := time.Unix(unix, 0)
+	if !now.Before(exp) {
+		return time.Time{}, ErrExpiredToken
+	}
+	return exp, nil
+}
+
+func (s *URLSigner) sig(key, exp string) string {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte(key))
+	m.Write([]byte{'\n'})
+	m.Write([]byte(exp))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
+
+// escapeKey экранирует каждый сегмент ключа, сохраняя "/"
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
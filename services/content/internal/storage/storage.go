@@ -1,11 +1,28 @@
 package storage
 
+import (
+	"io"
+	"time"
+)
+
+// если storage.presign_ttl не задан
+const defaultPresignTTL = 15 * time.Minute
+
 type PutResult struct {
 	Key  string // media_path
 	Size int64
 }
 
 type Storage interface {
-	Put(name string, data []byte, mime string) (PutResult, error)
+	// Put читает r до EOF; файл не держим в памяти целиком
+	Put(name string, r io.Reader, mime string) (PutResult, error)
 	Delete(key string) error
+	// Presign — временная ссылка на скачивание
+	Presign(key string, ttl time.Duration) (string, error)
+}
+
+// Lister — хранилище, которое умеет перечислить свои объекты (нужно сборщику мусора)
+type Lister interface {
+	// Walk вызывает fn для каждого объекта; ошибка из fn прерывает обход
+	Walk(fn func(key string, modTime time.Time) error) error
 }
@@ -0,0 +1,6 @@
+-- +goose Up
+-- GET /media/{key} ищет mime по пути файла
+CREATE INDEX IF NOT EXISTS idx_media_path ON media (path);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_media_path;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS media_variants (
+  media_id   uuid   NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+  name       text   NOT NULL,
+  path       text   NOT NULL,
+  mime       text   NOT NULL,
+  width      int    NOT NULL,
+  height     int    NOT NULL,
+  size       bigint NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (media_id, name)
+);
+
+-- GET /media/{key} ищет mime и по вариантам
+CREATE INDEX IF NOT EXISTS idx_media_variants_path ON media_variants (path);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_media_variants_path;
+DROP TABLE IF EXISTS media_variants;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- когда из файла вычищены EXIF/XMP/IPTC; NULL — файл не JPEG/PNG или загружен до очистки
+ALTER TABLE media
+  ADD COLUMN IF NOT EXISTS sanitized_at timestamptz;
+
+-- +goose Down
+ALTER TABLE media DROP COLUMN IF EXISTS sanitized_at;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- sha256 — хэш сохранённых байт: один файл в хранилище на одно содержимое.
+-- ref_count — сколько постов/аватарок ссылаются на медиа; 0 — кандидат на удаление.
+-- uploaded_at — последняя загрузка этого содержимого (повтор двигает её вперёд).
+ALTER TABLE media
+  ADD COLUMN IF NOT EXISTS sha256      text,
+  ADD COLUMN IF NOT EXISTS ref_count   int NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS uploaded_at timestamptz NOT NULL DEFAULT now();
+
+-- у старых строк хэша нет: NULL друг с другом не конфликтуют
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_sha256 ON media (sha256);
+
+-- ссылки на уже загруженные медиа: посты и аватарки
+UPDATE media m SET
+  ref_count = (SELECT count(*) FROM posts p WHERE p.media_id = m.id)
+            + (SELECT count(*) FROM users u WHERE u.avatar_path = m.path),
+  uploaded_at = m.created_at;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_media_sha256;
+ALTER TABLE media
+  DROP COLUMN IF EXISTS uploaded_at,
+  DROP COLUMN IF EXISTS ref_count,
+  DROP COLUMN IF EXISTS sha256;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- outbox: события пишутся в той же транзакции, что и данные, relay отправляет их в Kafka
+CREATE TABLE IF NOT EXISTS outbox (
+  id              bigserial   PRIMARY KEY,
+  topic           text        NOT NULL,
+  key             bytea,
+  payload         bytea       NOT NULL,
+  headers         jsonb       NOT NULL DEFAULT '{}',
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  attempts        int         NOT NULL DEFAULT 0,
+  last_error      text,
+  next_attempt_at timestamptz NOT NULL DEFAULT now(),
+  sent_at         timestamptz
+);
+
+-- relay выбирает только неотправленные
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (next_attempt_at, id) WHERE sent_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- мягкое удаление: пост остаётся в таблице, но не отдаётся и убирается из лент
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+-- +goose Down
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
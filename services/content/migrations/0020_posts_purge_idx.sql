@@ -0,0 +1,7 @@
+-- +goose Up
+-- сборщик ищет удалённые посты, которые ещё держат медиа (PurgeDeletedPosts)
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_media ON posts (deleted_at)
+  WHERE deleted_at IS NOT NULL AND media_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_posts_deleted_media;
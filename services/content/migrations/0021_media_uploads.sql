@@ -0,0 +1,20 @@
+-- +goose Up
+-- кто загружал медиа (с дедупликацией одно медиа может быть загружено разными пользователями):
+-- привязать медиа к аватарке можно только своё
+CREATE TABLE IF NOT EXISTS media_uploads (
+  media_id    uuid        NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+  user_id     uuid        NOT NULL,
+  uploaded_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (media_id, user_id)
+);
+
+-- загрузки до этой миграции: владельцы — авторы постов и хозяева аватарок
+INSERT INTO media_uploads (media_id, user_id, uploaded_at)
+SELECT p.media_id, p.author_id, p.created_at FROM posts p WHERE p.media_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+INSERT INTO media_uploads (media_id, user_id, uploaded_at)
+SELECT m.id, u.id, m.uploaded_at FROM users u JOIN media m ON m.path = u.avatar_path
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS media_uploads;
@@ -0,0 +1,158 @@
+// feed-dlq — просмотр и повторная отправка сообщений из DLQ feed.
+//
+//	feed-dlq list    [-n 50]   показать сообщения, ещё не возвращённые в работу
+//	feed-dlq redrive [-n 0]    отправить их обратно в исходный топик (0 — все)
+//
+// Прогресс redrive хранится в consumer group <kafka.group>-dlq-admin:
+// list ничего не коммитит, redrive коммитит каждое отправленное сообщение.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/events"
+	feedsvc "github.com/mariapetrova3009/insta-backend/services/feed/internal/feed"
+)
+
+const usage = "usage: feed-dlq list|redrive [-n N] [-idle 5s]"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd := os.Args[1]
+
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	limit := fs.Int("n", 0, "max messages (list: default 50, redrive: 0 — all)")
+	idle := fs.Duration("idle", 5*time.Second, "stop after no messages for this long")
+	_ = fs.Parse(os.Args[2:])
+
+	cfg, err := cfgpkg.Load("feed")
+	if err != nil {
+		fail(err)
+	}
+	topic := cfg.Kafka.Topics.FeedDLQ
+	if topic == "" {
+		fail(errors.New("kafka.topics.feed_dlq is not set"))
+	}
+
+	cons, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  strings.Join(cfg.Kafka.Brokers, ","),
+		"group.id":           cfg.Kafka.Group + "-dlq-admin",
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		fail(err)
+	}
+	defer cons.Close()
+	if err := cons.Subscribe(topic, nil); err != nil {
+		fail(err)
+	}
+
+	switch cmd {
+	case "list":
+		if *limit <= 0 {
+			*limit = 50
+		}
+		err = list(cons, *limit, *idle)
+	case "redrive":
+		err = redrive(cfg, cons, *limit, *idle)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func list(cons *kafka.Consumer, limit int, idle time.Duration) error {
+	n := 0
+	err := each(cons, idle, func(m *kafka.Message) (bool, error) {
+		h := m.Headers
+		fmt.Printf("%s[%d]@%s key=%s schema=%s\n", *m.TopicPartition.Topic, m.TopicPartition.Partition,
+			m.TopicPartition.Offset, m.Key, events.Schema(h))
+		fmt.Printf("  from %s[%s]@%s, failed at %s after %s attempts, redrives=%s\n",
+			feedsvc.Header(h, feedsvc.HeaderDLQTopic), feedsvc.Header(h, feedsvc.HeaderDLQPartition),
+			feedsvc.Header(h, feedsvc.HeaderDLQOffset), feedsvc.Header(h, feedsvc.HeaderDLQFailedAt),
+			feedsvc.Header(h, feedsvc.HeaderDLQAttempts), orZero(feedsvc.Header(h, feedsvc.HeaderRedrives)))
+		fmt.Printf("  error: %s\n", feedsvc.Header(h, feedsvc.HeaderDLQError))
+		n++
+		return n < limit, nil
+	})
+	fmt.Printf("%d message(s)\n", n)
+	return err
+}
+
+func redrive(cfg *cfgpkg.Config, cons *kafka.Consumer, limit int, idle time.Duration) error {
+	prod, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers":  strings.Join(cfg.Kafka.Brokers, ","),
+		"enable.idempotence": true,
+		"acks":               "all",
+	})
+	if err != nil {
+		return err
+	}
+	defer prod.Close()
+
+	n := 0
+	err = each(cons, idle, func(m *kafka.Message) (bool, error) {
+		out := feedsvc.Redrive(m, cfg.Kafka.Topics.PostCreated)
+		delivery := make(chan kafka.Event, 1)
+		if err := prod.Produce(out, delivery); err != nil {
+			return false, err
+		}
+		if dm, ok := (<-delivery).(*kafka.Message); ok && dm.TopicPartition.Error != nil {
+			return false, dm.TopicPartition.Error
+		}
+		// в исходный топик ушло — отмечаем в DLQ, чтобы не отправить второй раз
+		if _, err := cons.CommitMessage(m); err != nil {
+			return false, err
+		}
+		fmt.Printf("redriven %s[%d]@%s -> %s\n", *m.TopicPartition.Topic, m.TopicPartition.Partition,
+			m.TopicPartition.Offset, *out.TopicPartition.Topic)
+		n++
+		return limit <= 0 || n < limit, nil
+	})
+	fmt.Printf("%d message(s) redriven\n", n)
+	return err
+}
+
+// each читает DLQ, пока fn возвращает true и сообщения приходят чаще, чем раз в idle
+func each(cons *kafka.Consumer, idle time.Duration, fn func(*kafka.Message) (bool, error)) error {
+	for {
+		m, err := cons.ReadMessage(idle)
+		if err != nil {
+			var kerr kafka.Error
+			if errors.As(err, &kerr) && kerr.Code() == kafka.ErrTimedOut {
+				return nil
+			}
+			return err
+		}
+		more, err := fn(m)
+		if err != nil || !more {
+			return err
+		}
+	}
+}
+
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "feed-dlq:", err)
+	os.Exit(1)
+}
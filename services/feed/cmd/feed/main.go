@@ -15,11 +15,13 @@ import (
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
 	logpkg "github.com/mariapetrova3009/insta-backend/pkg/logger"
+	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
 	fdpb "github.com/mariapetrova3009/insta-backend/proto/feed"
 	feedsvc "github.com/mariapetrova3009/insta-backend/services/feed/internal/feed"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -36,6 +38,12 @@ func main() {
 	log := logpkg.New(cfg.Env, service, cfg.Log.Level, cfg.Log.Format)
 	log.Info("starting")
 
+	// без DLQ необработанное событие пришлось бы либо потерять, либо встать на нём
+	if cfg.Kafka.Topics.FeedDLQ == "" {
+		log.Error("kafka.topics.feed_dlq is not set")
+		return
+	}
+
 	db, err := sql.Open("postgres", cfg.Postgres.DSN)
 	if err != nil {
 		panic(err)
@@ -56,6 +64,31 @@ func main() {
 	}
 	defer cons.Close()
 
+	// producer только для DLQ
+	prod, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers":  strings.Join(cfg.Kafka.Brokers, ","),
+		"enable.idempotence": true,
+		"acks":               "all",
+	})
+	if err != nil {
+		log.Error("kafka producer init", "err", err)
+		return
+	}
+	defer prod.Close()
+	// DLQ ждёт свои delivery report'ы сам (produceSync); здесь — ошибки producer'а
+	go func() {
+		for e := range prod.Events() {
+			switch ev := e.(type) {
+			case *kafka.Message:
+				if ev.TopicPartition.Error != nil {
+					log.Error("delivery failed", "tp", ev.TopicPartition.String(), "err", ev.TopicPartition.Error)
+				}
+			case kafka.Error:
+				log.Error("kafka producer error", "err", ev)
+			}
+		}
+	}()
+
 	// HTTP /healthz
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
@@ -74,8 +107,16 @@ func main() {
 		reflection.Register(grpcSrv)
 	}
 
+	// content — для гидратации постов в ленте
+	ctConn, err := grpc.Dial(cfg.Content.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Error("content dial", "err", err)
+		return
+	}
+	defer ctConn.Close()
+
 	repo := feedsvc.NewRepo(db)
-	srv := feedsvc.New(log, repo, cons, cfg.Kafka.Topics.PostCreated)
+	srv := feedsvc.New(log, cfg, repo, contentpb.NewContentServiceClient(ctConn), cons, prod)
 	fdpb.RegisterFeedServiceServer(grpcSrv, srv)
 
 	// run services
@@ -98,9 +139,13 @@ func main() {
 		errCh <- grpcSrv.Serve(lis)
 	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go srv.RunConsumer(ctx)
+	consCtx, stopCons := context.WithCancel(context.Background())
+	defer stopCons()
+	consDone := make(chan struct{})
+	go func() {
+		defer close(consDone)
+		srv.RunConsumer(consCtx)
+	}()
 
 	// graceful shutdown
 	stop := make(chan os.Signal, 1)
@@ -113,7 +158,11 @@ func main() {
 		log.Error("server error", slog.Any("err", err))
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	// consumer останавливаем первым: прерванное сообщение не коммитится и перечитается;
+	// cons/prod закрываются (defer) только после его выхода
+	stopCons()
+	<-consDone
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	_ = httpSrv.Shutdown(ctx)
 	grpcSrv.GracefulStop()
@@ -0,0 +1,234 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/events"
+	evpb "github.com/mariapetrova3009/insta-backend/proto/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// если kafka.consumer.* не заданы
+const (
+	defaultMaxAttempts = 5
+	defaultMinBackoff  = 200 * time.Millisecond
+	defaultMaxBackoff  = 10 * time.Second
+)
+
+// сколько ждём delivery report от DLQ
+const dlqDeliveryTimeout = 10 * time.Second
+
+// retryPolicy — сколько раз пробуем обработать сообщение и паузы между попытками
+type retryPolicy struct {
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+func newRetryPolicy(cfg *cfgpkg.Config) retryPolicy {
+	p := retryPolicy{
+		maxAttempts: cfg.Kafka.Consumer.MaxAttempts,
+		minBackoff:  cfg.Kafka.Consumer.MinBackoff,
+		maxBackoff:  cfg.Kafka.Consumer.MaxBackoff,
+	}
+	if p.maxAttempts <= 0 {
+		p.maxAttempts = defaultMaxAttempts
+	}
+	if p.minBackoff <= 0 {
+		p.minBackoff = defaultMinBackoff
+	}
+	if p.maxBackoff < p.minBackoff {
+		p.maxBackoff = max(defaultMaxBackoff, p.minBackoff)
+	}
+	return p
+}
+
+// backoff перед попыткой attempt+1: min, 2*min, 4*min ... до max
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	d := p.minBackoff
+	for i := 1; i < attempt && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.maxBackoff)
+}
+
+// errPoison — сообщение не разобрать, повторять бессмысленно
+var errPoison = errors.New("undecodable event")
+
+func (s *Server) RunConsumer(ctx context.Context) {
+	if s.cons == nil || len(s.topics) == 0 {
+		return
+	}
+
+	if err := s.cons.SubscribeTopics(s.topics, nil); err != nil {
+		s.log.Error("kafka subscribe failed", "err", err)
+		return
+	}
+
+	s.log.Info("kafka consuming", "topics", s.topics, "dlq", s.topicDLQ)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("consumer stop")
+			return
+		default:
+			msg, err := s.cons.ReadMessage(250 * time.Millisecond) // 250ms poll
+			if err != nil {                                        // timeout/err — просто продолжим
+				var kerr kafka.Error
+				if errors.As(err, &kerr) && kerr.IsFatal() {
+					s.log.Error("kafka consumer fatal", "err", err)
+				}
+				continue
+			}
+
+			attempts, err := s.process(ctx, msg)
+			if err != nil {
+				if ctx.Err() != nil {
+					// остановка посреди повторов: offset не коммитим, сообщение перечитается
+					s.log.Info("consumer stop")
+					return
+				}
+				// повторы кончились — в DLQ; offset двигаем только когда DLQ принял сообщение
+				if !s.deadLetter(ctx, msg, err, attempts) {
+					return
+				}
+			}
+
+			// коммитим offset (ручной commit)
+			if _, err := s.cons.CommitMessage(msg); err != nil {
+				s.log.Warn("commit failed", "err", err)
+			}
+		}
+	}
+}
+
+// process разбирает и обрабатывает сообщение с повторами;
+// ошибка — последняя, attempts — сколько было попыток
+func (s *Server) process(ctx context.Context, msg *kafka.Message) (attempts int, err error) {
+	// тип и версия — по заголовку schema (старый JSON тоже понимаем)
+	evt, err := events.DecodeMessage(msg)
+	if err != nil {
+		s.log.Error("bad event", "schema", events.Schema(msg.Headers), "err", err)
+		return 1, fmt.Errorf("%w: %v", errPoison, err)
+	}
+
+	for attempts = 1; ; attempts++ {
+		err = s.handleEvent(ctx, evt)
+		if err == nil {
+			return attempts, nil
+		}
+		if attempts >= s.retry.maxAttempts {
+			s.log.Error("handle event failed, giving up", "attempts", attempts, "err", err)
+			return attempts, err
+		}
+		wait := s.retry.backoff(attempts)
+		s.log.Warn("handle event failed, retrying", "attempt", attempts, "in", wait, "err", err)
+		select {
+		case <-ctx.Done():
+			return attempts, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// handleEvent — обработка одного события; неизвестные типы пропускаем
+func (s *Server) handleEvent(ctx context.Context, evt proto.Message) error {
+	switch e := evt.(type) {
+	case *evpb.PostCreated:
+		return s.fanout(ctx, e.GetAuthorId(), e.GetPostId(), e.GetCreatedAt().AsTime())
+	case *evpb.PostDeleted:
+		start := time.Now()
+		n, err := s.repo.PurgePost(ctx, e.GetPostId(), s.fanoutOpt.ChunkSize)
+		if err == nil {
+			s.log.Info("post purged from feeds", "post_id", e.GetPostId(), "rows", n, "took", time.Since(start))
+		}
+		return err
+	case *evpb.FollowCreated:
+		// старые посты автора в ленту нового подписчика
+		n, err := s.repo.Backfill(ctx, e.GetFollowerId(), e.GetFolloweeId(), s.backfillPosts)
+		if err == nil {
+			s.log.Debug("feed backfilled", "follower_id", e.GetFollowerId(), "followee_id", e.GetFolloweeId(), "rows", n)
+		}
+		return err
+	case *evpb.FollowDeleted:
+		n, err := s.repo.PurgeAuthor(ctx, e.GetFollowerId(), e.GetFolloweeId())
+		if err == nil {
+			s.log.Debug("feed purged", "follower_id", e.GetFollowerId(), "followee_id", e.GetFolloweeId(), "rows", n)
+		}
+		return err
+	default:
+		s.log.Debug("event ignored", "type", evt.ProtoReflect().Descriptor().FullName())
+		return nil
+	}
+}
+
+// fanout — фан-аут поста подписчикам (или пометка автора как pull, см. Repo.FanoutPost)
+func (s *Server) fanout(ctx context.Context, authorID, postID string, createdAt time.Time) error {
+	start := time.Now()
+	st, err := s.repo.FanoutPost(ctx, authorID, postID, createdAt, s.fanoutOpt)
+	log := s.log.With("author_id", authorID, "post_id", postID,
+		"followers", st.Followers, "rows", st.Inserted, "chunks", st.Chunks,
+		"resumed", st.Resumed, "took", time.Since(start))
+	switch {
+	case err != nil:
+		log.Warn("fanout interrupted", "err", err) // продолжится с checkpoint при повторе
+		return err
+	case !st.Pushed:
+		log.Debug("post left for pull")
+	case st.Done:
+		log.Debug("fanout already done")
+	default:
+		log.Info("fanout done")
+	}
+	return nil
+}
+
+// deadLetter кладёт сообщение в DLQ и ждёт подтверждения; пока Kafka не примет,
+// повторяет (дальше по партиции не идём, иначе сообщение потеряется).
+// false — остановились по ctx или DLQ не настроен: offset не коммитим.
+func (s *Server) deadLetter(ctx context.Context, msg *kafka.Message, cause error, attempts int) bool {
+	if s.prod == nil || s.topicDLQ == "" {
+		// main без feed_dlq не стартует; сюда попадаем только при ручной сборке Server
+		s.log.Error("dlq not configured, consumer stopped without commit",
+			"partition", msg.TopicPartition.Partition, "offset", msg.TopicPartition.Offset, "err", cause)
+		return false
+	}
+	dlq := DeadLetter(msg, s.topicDLQ, cause, attempts, time.Now())
+	for try := 1; ; try++ {
+		err := s.produceSync(dlq)
+		if err == nil {
+			s.log.Warn("event moved to dlq", "topic", s.topicDLQ,
+				"partition", msg.TopicPartition.Partition, "offset", msg.TopicPartition.Offset, "err", cause)
+			return true
+		}
+		wait := s.retry.backoff(try)
+		s.log.Error("dlq produce failed", "attempt", try, "in", wait, "err", err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (s *Server) produceSync(msg *kafka.Message) error {
+	delivery := make(chan kafka.Event, 1)
+	if err := s.prod.Produce(msg, delivery); err != nil {
+		return err
+	}
+	select {
+	case e := <-delivery:
+		if m, ok := e.(*kafka.Message); ok {
+			return m.TopicPartition.Error
+		}
+		return nil
+	case <-time.After(dlqDeliveryTimeout):
+		return errors.New("dlq delivery timeout")
+	}
+}
@@ -0,0 +1,108 @@
+package feed
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+)
+
+// Position — место в ленте: (created_at, post_id) последней отданной записи
+type Position struct {
+	CreatedAt time.Time
+	PostID    string
+}
+
+// pageCursor — расшифрованный курсор: либо keyset-позиция, либо legacy offset
+type pageCursor struct {
+	after  *Position
+	offset int
+}
+
+// cursorCodec подписывает курсоры HMAC-ом (с привязкой к владельцу ленты),
+// чтобы клиент не мог подсунуть произвольную позицию.
+//
+// Формат: base64("k:<unix_nano>:<post_id>") + "." + base64(hmac[:16]).
+// Старые курсоры base64("o:<offset>") без подписи принимаются на переходный период.
+type cursorCodec struct {
+	key []byte
+}
+
+func newCursorCodec(cfg *cfgpkg.Config) cursorCodec {
+	key := cfg.Feed.CursorSecret
+	if key == "" {
+		key = cfg.JWT.Secret
+	}
+	return cursorCodec{key: []byte(key)}
+}
+
+func (c cursorCodec) encode(uid string, p Position) string {
+	payload := fmt.Sprintf("k:%d:%s", p.CreatedAt.UnixNano(), p.PostID)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(c.sign(uid, payload))
+}
+
+func (c cursorCodec) decode(uid, tok string) (pageCursor, error) {
+	rawPayload, rawSig, signed := strings.Cut(tok, ".")
+	b, err := base64.RawURLEncoding.DecodeString(rawPayload)
+	if err != nil {
+		return pageCursor{}, err
+	}
+	payload := string(b)
+
+	if !signed {
+		return decodeLegacyCursor(payload)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(rawSig)
+	if err != nil {
+		return pageCursor{}, err
+	}
+	if !hmac.Equal(sig, c.sign(uid, payload)) {
+		return pageCursor{}, errors.New("bad cursor signature")
+	}
+
+	parts := strings.SplitN(payload, ":", 3)
+	if len(parts) != 3 || parts[0] != "k" {
+		return pageCursor{}, errors.New("bad prefix")
+	}
+	ns, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return pageCursor{}, err
+	}
+	postID, err := uuid.Parse(parts[2])
+	if err != nil {
+		return pageCursor{}, err
+	}
+	return pageCursor{after: &Position{CreatedAt: time.Unix(0, ns).UTC(), PostID: postID.String()}}, nil
+}
+
+func (c cursorCodec) sign(uid, payload string) []byte {
+	m := hmac.New(sha256.New, c.key)
+	m.Write([]byte(uid))
+	m.Write([]byte{0})
+	m.Write([]byte(payload))
+	return m.Sum(nil)[:16]
+}
+
+// decodeLegacyCursor разбирает старый "o:<offset>"
+func decodeLegacyCursor(payload string) (pageCursor, error) {
+	if !strings.HasPrefix(payload, "o:") {
+		return pageCursor{}, errors.New("bad prefix")
+	}
+	o, err := strconv.Atoi(payload[2:])
+	if err != nil {
+		return pageCursor{}, err
+	}
+	if o < 0 {
+		o = 0
+	}
+	return pageCursor{offset: o}, nil
+}
@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// заголовки, которые consumer добавляет к сообщению в DLQ (оригинальные тоже сохраняются)
+const (
+	HeaderDLQPrefix    = "dlq-"
+	HeaderDLQError     = "dlq-error"
+	HeaderDLQAttempts  = "dlq-attempts"
+	HeaderDLQTopic     = "dlq-original-topic"
+	HeaderDLQPartition = "dlq-original-partition"
+	HeaderDLQOffset    = "dlq-original-offset"
+	HeaderDLQFailedAt  = "dlq-failed-at"
+	// HeaderRedrives — сколько раз сообщение уже возвращали из DLQ
+	HeaderRedrives = "redrive-count"
+)
+
+// DeadLetter — копия msg для DLQ: тот же key и payload + причина и откуда пришло
+func DeadLetter(msg *kafka.Message, dlqTopic string, cause error, attempts int, now time.Time) *kafka.Message {
+	tp := msg.TopicPartition
+	orig := ""
+	if tp.Topic != nil {
+		orig = *tp.Topic
+	}
+	headers := append([]kafka.Header(nil), msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderDLQError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDLQTopic, Value: []byte(orig)},
+		kafka.Header{Key: HeaderDLQPartition, Value: []byte(strconv.Itoa(int(tp.Partition)))},
+		kafka.Header{Key: HeaderDLQOffset, Value: []byte(tp.Offset.String())},
+		kafka.Header{Key: HeaderDLQFailedAt, Value: []byte(now.UTC().Format(time.RFC3339))},
+	)
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &dlqTopic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+	}
+}
+
+// Redrive — сообщение из DLQ обратно в исходный топик (или в fallback, если заголовка нет):
+// dlq-* заголовки убираются, redrive-count увеличивается
+func Redrive(msg *kafka.Message, fallback string) *kafka.Message {
+	topic := Header(msg.Headers, HeaderDLQTopic)
+	if topic == "" {
+		topic = fallback
+	}
+	redrives, _ := strconv.Atoi(Header(msg.Headers, HeaderRedrives))
+
+	var headers []kafka.Header
+	for _, h := range msg.Headers {
+		if strings.HasPrefix(h.Key, HeaderDLQPrefix) || h.Key == HeaderRedrives {
+			continue
+		}
+		headers = append(headers, h)
+	}
+	headers = append(headers, kafka.Header{Key: HeaderRedrives, Value: []byte(strconv.Itoa(redrives + 1))})
+
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+	}
+}
+
+// Header — значение заголовка ("" если нет)
+func Header(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
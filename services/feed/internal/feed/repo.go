@@ -3,7 +3,7 @@ package feed
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
 	"time"
 )
 
@@ -21,48 +21,207 @@ type DBFeed struct {
 	createdAt time.Time
 }
 
-func (r *Repo) FanoutPost(ctx context.Context, authorID, postID string, createdAt time.Time) error {
-	// transaction
+// FanoutOptions — параметры фан-аута
+type FanoutOptions struct {
+	Threshold int // больше подписчиков — автор pull (0 — всегда push)
+	ChunkSize int // подписчиков на одну транзакцию
+}
+
+// FanoutStats — что сделал FanoutPost
+type FanoutStats struct {
+	Pushed    bool // false — автор pull, строки не писались
+	Done      bool // фан-аут этого поста уже был закончен раньше
+	Resumed   bool // продолжили с checkpoint
+	Followers int  // подписчиков обработано в этом вызове
+	Inserted  int  // новых строк feed_entries
+	Chunks    int
+}
+
+// сколько храним прогресс законченных фан-аутов (защита от повторных событий)
+const fanoutProgressRetention = "7 days"
+
+// FanoutPost раскладывает пост по лентам подписчиков (push) кусками по ChunkSize:
+// каждый кусок — одна транзакция с INSERT ... SELECT и сдвигом checkpoint'а в feed_fanout_progress,
+// так что после падения фан-аут продолжится с последнего закоммиченного подписчика.
+// Если у автора больше Threshold подписчиков или он уже в feed_pull_authors,
+// строки не пишутся: автор помечается pull и его посты читаются в GetPulled.
+func (r *Repo) FanoutPost(ctx context.Context, authorID, postID string, createdAt time.Time, opt FanoutOptions) (FanoutStats, error) {
+	var st FanoutStats
+
+	var (
+		last sql.NullString
+		done sql.NullTime
+	)
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT last_follower, done_at FROM feed_fanout_progress WHERE post_id = $1`, postID).Scan(&last, &done)
+	switch {
+	case err == nil:
+		// push уже выбран раньше, продолжаем
+		st.Pushed, st.Resumed, st.Done = true, !done.Valid, done.Valid
+		if done.Valid {
+			return st, nil
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		pull, err := r.markPull(ctx, authorID, opt.Threshold)
+		if err != nil || pull {
+			return st, err
+		}
+		st.Pushed = true
+	default:
+		return st, err
+	}
+
+	chunk := max(opt.ChunkSize, 1)
+	for {
+		n, inserted, next, err := r.fanoutChunk(ctx, authorID, postID, createdAt, last, chunk)
+		if err != nil {
+			return st, err
+		}
+		st.Chunks++
+		st.Followers += n
+		st.Inserted += inserted
+		if n < chunk {
+			break
+		}
+		last = sql.NullString{String: next, Valid: true}
+	}
+
+	_, err = r.DB.ExecContext(ctx,
+		`INSERT INTO feed_fanout_progress (post_id, author_id, done_at) VALUES ($1, $2, now())
+	ON CONFLICT (post_id) DO UPDATE SET done_at = now(), updated_at = now()`, postID, authorID)
+	if err != nil {
+		return st, err
+	}
+	_, err = r.DB.ExecContext(ctx,
+		`DELETE FROM feed_fanout_progress WHERE done_at < now() - $1::interval`, fanoutProgressRetention)
+	return st, err
+}
+
+// markPull решает push/pull для нового поста; true — автор pull, фан-аут не нужен
+func (r *Repo) markPull(ctx context.Context, authorID string, threshold int) (bool, error) {
+	if threshold <= 0 {
+		return false, nil
+	}
+	// подписчиков считаем не дальше threshold+1 — точное число не нужно
+	var pull bool
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM feed_pull_authors WHERE author_id = $1)
+		OR (SELECT count(*) FROM (SELECT 1 FROM follows WHERE followee_id = $1 LIMIT $2 + 1) f) > $2`,
+		authorID, threshold).Scan(&pull)
+	if err != nil || !pull {
+		return false, err
+	}
+	_, err = r.DB.ExecContext(ctx,
+		`INSERT INTO feed_pull_authors (author_id) VALUES ($1) ON CONFLICT DO NOTHING`, authorID)
+	return err == nil, err
+}
+
+// fanoutChunk — один кусок: следующие limit подписчиков после after,
+// вставка одним INSERT ... SELECT и checkpoint в той же транзакции.
+// n — сколько подписчиков в куске, next — последний из них.
+func (r *Repo) fanoutChunk(ctx context.Context, authorID, postID string, createdAt time.Time,
+	after sql.NullString, limit int) (n, inserted int, next string, err error) {
 	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, 0, "", err
 	}
 	defer func() {
 		_ = tx.Rollback()
 	}()
 
-	// получаем подписчика
-	q1 := `SELECT follower_id FROM follows WHERE folowee_id = $1`
-	rows, err := tx.QueryContext(ctx, q1, authorID)
+	var last sql.NullString
+	err = tx.QueryRowContext(ctx,
+		`WITH chunk AS (
+		SELECT follower_id FROM follows
+		WHERE followee_id = $1 AND ($4::uuid IS NULL OR follower_id > $4::uuid)
+		ORDER BY follower_id
+		LIMIT $5
+	), ins AS (
+		INSERT INTO feed_entries (user_id, post_id, created_at)
+		SELECT follower_id, $2, $3 FROM chunk
+		-- пост удалили раньше, чем дошёл фан-аут: PostDeleted уже обработан, не воскрешаем
+		WHERE NOT EXISTS (SELECT 1 FROM posts WHERE id = $2 AND deleted_at IS NOT NULL)
+		ON CONFLICT (user_id, post_id) DO NOTHING
+		RETURNING 1
+	)
+	SELECT (SELECT count(*) FROM chunk),
+		(SELECT count(*) FROM ins),
+		(SELECT follower_id FROM chunk ORDER BY follower_id DESC LIMIT 1)`,
+		authorID, postID, createdAt, after, limit).Scan(&n, &inserted, &last)
 	if err != nil {
-		return err
+		return 0, 0, "", err
 	}
-	defer rows.Close()
 
-	// вставляем подписчика и указатель на пост
-	q2 := `INSERT INTO feed_entries (user_id, post_id, created_at)
-	VALUES ($1, $2, $3)
-	ON CONFLICT (user_id, post_id) DO NOTHING`
-	stmt, err := tx.PrepareContext(ctx, q2)
+	if n > 0 {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO feed_fanout_progress (post_id, author_id, last_follower, rows)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id) DO UPDATE SET
+			last_follower = EXCLUDED.last_follower,
+			rows = feed_fanout_progress.rows + EXCLUDED.rows,
+			updated_at = now()`,
+			postID, authorID, last, inserted)
+		if err != nil {
+			return 0, 0, "", err
+		}
+	}
+	return n, inserted, last.String, tx.Commit()
+}
+
+// Backfill добавляет в ленту followerID последние limit постов followeeID.
+// Ничего не делает, если подписки уже нет (unfollow обогнал событие)
+// или автор pull — его посты и так подмешиваются при чтении.
+func (r *Repo) Backfill(ctx context.Context, followerID, followeeID string, limit int) (int64, error) {
+	res, err := r.DB.ExecContext(ctx,
+		`INSERT INTO feed_entries (user_id, post_id, created_at)
+	SELECT $1, p.id, p.created_at FROM posts p
+	WHERE p.author_id = $2 AND p.deleted_at IS NULL
+		AND EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2)
+		AND NOT EXISTS (SELECT 1 FROM feed_pull_authors WHERE author_id = $2)
+	ORDER BY p.created_at DESC
+	LIMIT $3
+	ON CONFLICT (user_id, post_id) DO NOTHING`, followerID, followeeID, limit)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	defer stmt.Close()
+	return res.RowsAffected()
+}
 
-	for rows.Next() {
-		var follower string
-		if err := rows.Scan(&follower); err != nil {
-			return err
+// PurgeAuthor убирает из ленты followerID посты followeeID.
+// Если подписка снова есть (подписался обратно раньше, чем дошло событие), ничего не трогает.
+func (r *Repo) PurgeAuthor(ctx context.Context, followerID, followeeID string) (int64, error) {
+	res, err := r.DB.ExecContext(ctx,
+		`DELETE FROM feed_entries fe
+	USING posts p
+	WHERE fe.user_id = $1 AND fe.post_id = p.id AND p.author_id = $2
+		AND NOT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2)`,
+		followerID, followeeID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// PurgePost убирает пост из всех лент кусками по chunk строк (каждый кусок — своя транзакция)
+func (r *Repo) PurgePost(ctx context.Context, postID string, chunk int) (int64, error) {
+	var total int64
+	for {
+		res, err := r.DB.ExecContext(ctx,
+			`DELETE FROM feed_entries WHERE ctid IN (
+			SELECT ctid FROM feed_entries WHERE post_id = $1 LIMIT $2
+		)`, postID, chunk)
+		if err != nil {
+			return total, err
 		}
-		if _, err := stmt.ExecContext(ctx, follower, postID, createdAt); err != nil {
-			return err
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < int64(chunk) {
+			return total, nil
 		}
 	}
-	if err := rows.Err(); err != nil {
-		return err
-	}
-	return tx.Commit()
-
 }
 
 type EntryLow struct {
@@ -71,16 +230,53 @@ type EntryLow struct {
 	CrearedAt time.Time
 }
 
-func (r *Repo) GetFeed(ctx context.Context, userID string, limit uint32, offset int) ([]EntryLow, error) {
-	q := `SELECT * FROM feed_entries %s ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	args := []any{limit, offset}
+// GetFeed — keyset-пагинация по (created_at, post_id) через idx_feed_entries_user_created.
+// after == nil — первая страница, иначе всё строго "старше" позиции курсора.
+func (r *Repo) GetFeed(ctx context.Context, userID string, limit uint32, after *Position) ([]EntryLow, error) {
+	q := `SELECT user_id, post_id, created_at FROM feed_entries
+	WHERE user_id = $1
+	ORDER BY created_at DESC, post_id DESC LIMIT $2`
+	args := []any{userID, limit}
+	if after != nil {
+		q = `SELECT user_id, post_id, created_at FROM feed_entries
+		WHERE user_id = $1 AND (created_at, post_id) < ($3, $4)
+		ORDER BY created_at DESC, post_id DESC LIMIT $2`
+		args = append(args, after.CreatedAt, after.PostID)
+	}
+	return r.queryEntries(ctx, limit, q, args...)
+}
+
+// GetFeedOffset — старая OFFSET-выдача; нужна только для legacy "o:" курсоров,
+// которые ещё живут у клиентов. Удалить после переходного периода.
+func (r *Repo) GetFeedOffset(ctx context.Context, userID string, limit uint32, offset int) ([]EntryLow, error) {
+	q := `SELECT user_id, post_id, created_at FROM feed_entries
+	WHERE user_id = $1
+	ORDER BY created_at DESC, post_id DESC LIMIT $2 OFFSET $3`
+	return r.queryEntries(ctx, limit, q, userID, limit, offset)
+}
 
-	where := ""
-	if userID != "" {
-		where = "WHERE user_id = $3"
-		args = []any{limit, offset, userID}
+// GetPulled — посты pull-авторов, на которых подписан userID, в том же порядке и
+// с тем же курсором, что и GetFeed: страницы двух источников можно сливать
+func (r *Repo) GetPulled(ctx context.Context, userID string, limit uint32, after *Position) ([]EntryLow, error) {
+	q := `SELECT $1::uuid, p.id, p.created_at FROM posts p
+	JOIN feed_pull_authors a ON a.author_id = p.author_id
+	JOIN follows f ON f.followee_id = p.author_id AND f.follower_id = $1
+	WHERE p.deleted_at IS NULL
+	ORDER BY p.created_at DESC, p.id DESC LIMIT $2`
+	args := []any{userID, limit}
+	if after != nil {
+		q = `SELECT $1::uuid, p.id, p.created_at FROM posts p
+		JOIN feed_pull_authors a ON a.author_id = p.author_id
+		JOIN follows f ON f.followee_id = p.author_id AND f.follower_id = $1
+		WHERE p.deleted_at IS NULL AND (p.created_at, p.id) < ($3, $4)
+		ORDER BY p.created_at DESC, p.id DESC LIMIT $2`
+		args = append(args, after.CreatedAt, after.PostID)
 	}
-	rows, err := r.DB.QueryContext(ctx, fmt.Sprintf(q, where), args...)
+	return r.queryEntries(ctx, limit, q, args...)
+}
+
+func (r *Repo) queryEntries(ctx context.Context, limit uint32, q string, args ...any) ([]EntryLow, error) {
+	rows, err := r.DB.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
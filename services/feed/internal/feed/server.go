@@ -2,18 +2,18 @@ package feed
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
 	"log/slog"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
+	authpkg "github.com/mariapetrova3009/insta-backend/pkg/auth"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
 	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
+	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
 	fdpb "github.com/mariapetrova3009/insta-backend/proto/feed"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -25,74 +25,71 @@ type item struct {
 // gRPC server realisation
 type Server struct {
 	fdpb.UnimplementedFeedServiceServer
-	log *slog.Logger
+	log       *slog.Logger
+	jwtSecret []byte
 
-	repo             *Repo
-	cons             *kafka.Consumer
-	topicPostCreated string
+	cursors       cursorCodec
+	repo          *Repo
+	content       contentpb.ContentServiceClient
+	cons          *kafka.Consumer
+	prod          *kafka.Producer // для DLQ
+	topics        []string        // всё, что читает consumer
+	topicDLQ      string
+	retry         retryPolicy
+	fanoutOpt     FanoutOptions
+	backfillPosts int
 }
 
-func New(log *slog.Logger, repo *Repo, cons *kafka.Consumer, topic string) *Server {
+func New(log *slog.Logger, cfg *cfgpkg.Config, repo *Repo, content contentpb.ContentServiceClient,
+	cons *kafka.Consumer, prod *kafka.Producer) *Server {
+	backfill := cfg.Feed.BackfillPosts
+	if backfill <= 0 {
+		backfill = defaultBackfillPosts
+	}
 	return &Server{
-		log:              log,
-		repo:             repo,
-		cons:             cons,
-		topicPostCreated: topic,
+		log:           log,
+		jwtSecret:     []byte(cfg.JWT.Secret),
+		cursors:       newCursorCodec(cfg),
+		repo:          repo,
+		content:       content,
+		cons:          cons,
+		prod:          prod,
+		topics:        consumerTopics(cfg),
+		topicDLQ:      cfg.Kafka.Topics.FeedDLQ,
+		retry:         newRetryPolicy(cfg),
+		fanoutOpt:     newFanoutOptions(cfg),
+		backfillPosts: backfill,
 	}
 }
 
-type postCreated struct {
-	PostID      string `json:"post_id"`
-	AuthorID    string `json:"author_id"`
-	Caption     string `json:"caption"`
-	MediaPath   string `json:"media_path"`
-	Mime        string `json:"mime"`
-	CreatedAtMs int64  `json:"created_at_ms"`
-}
-
-func (s *Server) RunConsumer(ctx context.Context) {
-	if s.cons == nil || s.topicPostCreated == "" {
-		return
-	}
+// если feed.fanout_chunk_size / feed.backfill_posts не заданы
+const (
+	defaultFanoutChunk   = 1000
+	defaultBackfillPosts = 20
+)
 
-	if err := s.cons.Subscribe(s.topicPostCreated, nil); err != nil {
-		s.log.Error("kafka subscribe failed", "err", err)
-		return
+// consumerTopics — новые и удалённые посты, подписки/отписки (пустые в конфиге пропускаем)
+func consumerTopics(cfg *cfgpkg.Config) []string {
+	var out []string
+	for _, t := range []string{
+		cfg.Kafka.Topics.PostCreated,
+		cfg.Kafka.Topics.PostDeleted,
+		cfg.Kafka.Topics.FollowCreated,
+		cfg.Kafka.Topics.FollowDeleted,
+	} {
+		if t != "" {
+			out = append(out, t)
+		}
 	}
+	return out
+}
 
-	s.log.Info("kafka consuming", "topic", s.topicPostCreated)
-
-	for {
-		select {
-		case <-ctx.Done():
-			s.log.Info("consumer stop")
-			return
-		default:
-			msg, err := s.cons.ReadMessage(250 * time.Millisecond) // 250ms poll
-			if err != nil {                                        // timeout/err — просто продолжим
-				// можно логировать только fatals: if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.IsFatal()
-				continue
-			}
-
-			var evt postCreated
-			if err := json.Unmarshal(msg.Value, &evt); err != nil {
-				s.log.Error("bad event json", "err", err)
-				continue
-			}
-
-			// фан-аут поста подписчикам
-			createdAt := time.UnixMilli(evt.CreatedAtMs).UTC()
-			if err := s.repo.FanoutPost(context.Background(), evt.AuthorID, evt.PostID, createdAt); err != nil {
-				s.log.Error("fanout failed", "err", err)
-				continue
-			}
-
-			// коммитим offset (ручной commit)
-			if _, err := s.cons.CommitMessage(msg); err != nil {
-				s.log.Warn("commit failed", "err", err)
-			}
-		}
+func newFanoutOptions(cfg *cfgpkg.Config) FanoutOptions {
+	chunk := cfg.Feed.FanoutChunkSize
+	if chunk <= 0 {
+		chunk = defaultFanoutChunk
 	}
+	return FanoutOptions{Threshold: cfg.Feed.PushThreshold, ChunkSize: chunk}
 }
 
 func (s *Server) AddPost(authorID string, post *cmpb.Post) {
@@ -101,10 +98,15 @@ func (s *Server) AddPost(authorID string, post *cmpb.Post) {
 	}
 
 	ctx := context.Background()
-	_ = s.repo.FanoutPost(ctx, authorID, post.Id, time.Now().UTC())
+	_ = s.fanout(ctx, authorID, post.Id, time.Now().UTC())
 }
 
 func (s *Server) GetFeed(ctx context.Context, req *fdpb.GetFeedRequest) (*fdpb.GetFeedResponse, error) {
+	// 0) владелец ленты — из JWT
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
@@ -118,38 +120,58 @@ func (s *Server) GetFeed(ctx context.Context, req *fdpb.GetFeedRequest) (*fdpb.G
 		}
 	}
 
-	// 2) cursor -> offset
-	offset := 0
+	// 2) cursor -> позиция (или legacy offset)
+	var cur pageCursor
 	if req.Page != nil && req.Page.Cursor != nil {
 		if tok := strings.TrimSpace(req.Page.Cursor.Token); tok != "" {
-			o, err := decodeCursor(tok) // уже есть у тебя
+			c, err := s.cursors.decode(uid, tok)
 			if err != nil {
 				return nil, status.Error(codes.InvalidArgument, "bad cursor")
 			}
-			if o > 0 {
-				offset = o
-			}
+			cur = c
 		}
 	}
 
-	rows, err := s.repo.GetFeed(ctx, "", limit, offset)
+	// берём на одну строку больше, чтобы честно знать has_more
+	var rows []EntryLow
+	if cur.after == nil && cur.offset > 0 {
+		// legacy offset — только push-записи: смещение по слитой выдаче не воспроизвести
+		rows, err = s.repo.GetFeedOffset(ctx, uid, limit+1, cur.offset)
+	} else {
+		rows, err = s.page(ctx, uid, limit+1, cur.after)
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "db error")
 	}
+	hasMore := uint32(len(rows)) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	posts, err := s.hydrate(ctx, uid, rows)
+	if err != nil {
+		return nil, err
+	}
 
 	entries := make([]*fdpb.FeedEntry, 0, len(rows))
 	for _, r := range rows {
+		p, ok := posts[r.PostID]
+		if !ok {
+			// пост удалён (content не отдаёт удалённые), а PostDeleted ещё не вычистил запись
+			// или пост исчез совсем — показывать нечего
+			continue
+		}
 		entries = append(entries, &fdpb.FeedEntry{
 			UserId: r.UserID,
-
-			Post: &cmpb.Post{Id: r.PostID},
+			Post:   p,
 		})
 	}
 
+	// следующий курсор всегда keyset, даже если пришёл legacy offset
 	var next *cmpb.Cursor
-	if uint32(len(rows)) == limit {
-		nextOff := offset + int(limit)
-		next = &cmpb.Cursor{Token: encodeCursor(nextOff)}
+	if hasMore {
+		last := rows[len(rows)-1]
+		next = &cmpb.Cursor{Token: s.cursors.encode(uid, Position{CreatedAt: last.CrearedAt, PostID: last.PostID})}
 	}
 
 	return &fdpb.GetFeedResponse{
@@ -161,20 +183,75 @@ func (s *Server) GetFeed(ctx context.Context, req *fdpb.GetFeedRequest) (*fdpb.G
 	}, nil
 }
 
-func encodeCursor(offset int) string {
-	s := fmt.Sprintf("o:%d", offset)
-	return base64.RawURLEncoding.EncodeToString([]byte(s))
+// page — страница ленты: push-записи из feed_entries + посты pull-авторов.
+// Оба источника отсортированы по (created_at, post_id) DESC и режутся одним курсором,
+// поэтому слияние с обрезкой до limit даёт стабильную пагинацию.
+func (s *Server) page(ctx context.Context, uid string, limit uint32, after *Position) ([]EntryLow, error) {
+	pushed, err := s.repo.GetFeed(ctx, uid, limit, after)
+	if err != nil {
+		return nil, err
+	}
+	pulled, err := s.repo.GetPulled(ctx, uid, limit, after)
+	if err != nil {
+		return nil, err
+	}
+	return mergeEntries(pushed, pulled, limit), nil
 }
 
-func decodeCursor(cur string) (int, error) {
-	b, err := base64.RawURLEncoding.DecodeString(cur)
+// mergeEntries сливает две отсортированные выдачи; пост, попавший в обе
+// (автор стал pull уже после рассылки), остаётся один раз
+func mergeEntries(a, b []EntryLow, limit uint32) []EntryLow {
+	out := make([]EntryLow, 0, min(uint32(len(a)+len(b)), limit))
+	seen := make(map[string]bool, len(a)+len(b))
+	for uint32(len(out)) < limit && (len(a) > 0 || len(b) > 0) {
+		var e EntryLow
+		if len(b) == 0 || (len(a) > 0 && entryBefore(a[0], b[0])) {
+			e, a = a[0], a[1:]
+		} else {
+			e, b = b[0], b[1:]
+		}
+		if seen[e.PostID] {
+			continue
+		}
+		seen[e.PostID] = true
+		out = append(out, e)
+	}
+	return out
+}
+
+// entryBefore — x идёт в ленте раньше y: новее, при равенстве — больший post_id (как в SQL)
+func entryBefore(x, y EntryLow) bool {
+	if !x.CrearedAt.Equal(y.CrearedAt) {
+		return x.CrearedAt.After(y.CrearedAt)
+	}
+	return x.PostID > y.PostID
+}
+
+// hydrate забирает полные посты страницы одним BatchGetPosts;
+// user-id уходит в metadata, чтобы content посчитал liked_by_me для владельца ленты
+func (s *Server) hydrate(ctx context.Context, uid string, rows []EntryLow) (map[string]*cmpb.Post, error) {
+	if len(rows) == 0 {
+		return map[string]*cmpb.Post{}, nil
+	}
+	ids := make([]string, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.PostID)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "user-id", uid)
+	res, err := s.content.BatchGetPosts(ctx, &contentpb.BatchGetPostsRequest{PostIds: ids})
 	if err != nil {
-		return 0, err
+		s.log.Error("hydrate posts failed", "err", err)
+		return nil, status.Error(codes.Unavailable, "content unavailable")
 	}
-	s := string(b)
-	if !strings.HasPrefix(s, "o") {
-		return 0, fmt.Errorf("bad prefix")
+
+	out := make(map[string]*cmpb.Post, len(res.GetPosts()))
+	for _, p := range res.GetPosts() {
+		out[p.GetId()] = p
 	}
+	return out, nil
+}
 
-	return strconv.Atoi(s[2:])
+func (s *Server) userIDFromAuth(ctx context.Context) (string, error) {
+	return authpkg.UserIDFromIncoming(ctx, s.jwtSecret)
 }
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Авторы, чьи посты не раскладываются по лентам подписчиков (слишком много подписчиков),
+-- а подмешиваются в GetFeed при чтении. Попав сюда, автор остаётся: иначе его старые
+-- посты пропали бы из лент, когда число подписчиков снова опустится ниже порога.
+CREATE TABLE IF NOT EXISTS feed_pull_authors (
+  author_id uuid PRIMARY KEY,
+  since     timestamptz NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS feed_pull_authors;
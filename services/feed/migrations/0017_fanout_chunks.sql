@@ -0,0 +1,22 @@
+-- +goose Up
+-- фан-аут идёт кусками по follower_id: нужен порядок подписчиков внутри автора
+CREATE INDEX IF NOT EXISTS idx_follows_followee_follower ON follows (followee_id, follower_id);
+DROP INDEX IF EXISTS idx_follows_followee;
+
+-- прогресс фан-аута поста: после падения продолжаем с last_follower,
+-- повторное событие по законченному посту (done_at) пропускаем
+CREATE TABLE IF NOT EXISTS feed_fanout_progress (
+  post_id       uuid PRIMARY KEY,
+  author_id     uuid NOT NULL,
+  last_follower uuid,
+  rows          int  NOT NULL DEFAULT 0,
+  started_at    timestamptz NOT NULL DEFAULT now(),
+  updated_at    timestamptz NOT NULL DEFAULT now(),
+  done_at       timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_feed_fanout_progress_done ON feed_fanout_progress (done_at) WHERE done_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS feed_fanout_progress;
+CREATE INDEX IF NOT EXISTS idx_follows_followee ON follows (followee_id);
+DROP INDEX IF EXISTS idx_follows_followee_follower;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- удаление поста из всех лент (PostDeleted) ищет записи по post_id
+CREATE INDEX IF NOT EXISTS idx_feed_entries_post ON feed_entries (post_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_feed_entries_post;
@@ -5,6 +5,7 @@ import (
 
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
 	logpkg "github.com/mariapetrova3009/insta-backend/pkg/logger"
+	"github.com/mariapetrova3009/insta-backend/pkg/revoke"
 
 	gatewayclients "github.com/mariapetrova3009/insta-backend/services/gateway/internal/clients"
 	gatewayhttp "github.com/mariapetrova3009/insta-backend/services/gateway/internal/http"
@@ -19,8 +20,15 @@ func main() {
 	}
 	log := logpkg.New(cfg.Env, service, cfg.Log.Level, cfg.Log.Format)
 
+	// отзыв пишет identity, проверяет gateway — хранилище должно быть общим
+	revoked, err := revoke.FromConfig(cfg)
+	if err != nil {
+		log.Error("revocation store", "err", err)
+		return
+	}
+
 	cl := gatewayclients.MustInit(cfg)
-	r := gatewayhttp.NewRouter(log, cfg, cl)
+	r := gatewayhttp.NewRouter(log, cfg, cl, revoked)
 
 	log.Info("http listen", "addr", cfg.HTTP.Addr)
 	if err := http.ListenAndServe(cfg.HTTP.Addr, r); err != nil {
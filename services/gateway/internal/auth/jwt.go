@@ -2,10 +2,12 @@ package auth
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
+	authpkg "github.com/mariapetrova3009/insta-backend/pkg/auth"
+	"github.com/mariapetrova3009/insta-backend/pkg/revoke"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -34,30 +36,42 @@ func Outgoing(ctx context.Context, md metadata.MD) context.Context {
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
-// HTTP-middleware for check token`s Bearer
-func JWTMiddleware(secret []byte) func(http.Handler) http.Handler {
+// ErrorWriter пишет ответ с ошибкой (в gateway — единый JSON-формат из http/response.go)
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, code int, msg string)
+
+// HTTP-middleware for check token`s Bearer (+ revocation list).
+// failOpen — пускать, если хранилище отзыва недоступно; иначе 503.
+func JWTMiddleware(secret []byte, revoked revoke.Store, failOpen bool, writeError ErrorWriter) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			a := r.Header.Get("Authorization")
 			if a == "" || !strings.HasPrefix(strings.ToLower(a), "bearer ") {
-				http.Error(w, "missing token", http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, "missing token")
 				return
 			}
 
 			tokenStr := strings.TrimSpace(a[len("Bearer "):])
-			tok, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-				// при необходимости проверь alg (например, t.Method.Alg() == jwt.SigningMethodHS256.Alg())
-				return secret, nil
-			})
-			if err != nil || !tok.Valid {
-				http.Error(w, "invalid token", http.StatusUnauthorized)
+			claims, err := authpkg.ParseToken(tokenStr, secret)
+			if err != nil {
+				writeError(w, r, http.StatusUnauthorized, "invalid token")
+				return
+			}
+
+			isRevoked, err := revoke.IsClaimsRevoked(r.Context(), revoked, claims)
+			if err != nil {
+				slog.Warn("revocation check failed", "err", err)
+				if !failOpen {
+					writeError(w, r, http.StatusServiceUnavailable, "revocation check unavailable")
+					return
+				}
+			}
+			if isRevoked {
+				writeError(w, r, http.StatusUnauthorized, "token revoked")
 				return
 			}
 
-			claims := tok.Claims.(jwt.MapClaims)
-			uid, _ := claims["sub"].(string)
-			ctx := context.WithValue(r.Context(), "user-id", uid)
+			ctx := context.WithValue(r.Context(), "user-id", claims.Subject)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 
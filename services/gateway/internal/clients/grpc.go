@@ -2,33 +2,45 @@ package clients
 
 import (
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	cmtpb "github.com/mariapetrova3009/insta-backend/proto/comments"
 	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
 	feedpb "github.com/mariapetrova3009/insta-backend/proto/feed"
 	idpb "github.com/mariapetrova3009/insta-backend/proto/identity"
+	socialpb "github.com/mariapetrova3009/insta-backend/proto/social"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Clients struct {
-	Identity               idpb.IdentityServiceClient
-	Content                contentpb.ContentServiceClient
-	Feed                   feedpb.FeedServiceClient
-	idConn, ctConn, fdConn *grpc.ClientConn
+	Identity                               idpb.IdentityServiceClient
+	Content                                contentpb.ContentServiceClient
+	Feed                                   feedpb.FeedServiceClient
+	Social                                 socialpb.SocialGraphServiceClient
+	Likes                                  socialpb.LikeServiceClient
+	Comments                               cmtpb.CommentServiceClient
+	idConn, ctConn, fdConn, scConn, cmConn *grpc.ClientConn
 }
 
 func MustInit(cfg *cfgpkg.Config) *Clients {
 	idConn, _ := grpc.Dial(cfg.Identity.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	ctConn, _ := grpc.Dial(cfg.Content.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	fdConn, _ := grpc.Dial(cfg.Feed.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	scConn, _ := grpc.Dial(cfg.Social.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cmConn, _ := grpc.Dial(cfg.Comments.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	return &Clients{
 		Identity: idpb.NewIdentityServiceClient(idConn),
 		Content:  contentpb.NewContentServiceClient(ctConn),
 		Feed:     feedpb.NewFeedServiceClient(fdConn),
-		idConn:   idConn, ctConn: ctConn, fdConn: fdConn,
+		Social:   socialpb.NewSocialGraphServiceClient(scConn),
+		Likes:    socialpb.NewLikeServiceClient(scConn),
+		Comments: cmtpb.NewCommentServiceClient(cmConn),
+		idConn:   idConn, ctConn: ctConn, fdConn: fdConn, scConn: scConn, cmConn: cmConn,
 	}
 }
 func (c *Clients) Close() {
 	_ = c.idConn.Close()
 	_ = c.ctConn.Close()
 	_ = c.fdConn.Close()
+	_ = c.scConn.Close()
+	_ = c.cmConn.Close()
 }
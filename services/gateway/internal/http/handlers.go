@@ -3,19 +3,21 @@ package http
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/go-chi/chi/v5"
 	gatewayauth "github.com/mariapetrova3009/insta-backend/services/gateway/internal/auth"
 	"github.com/mariapetrova3009/insta-backend/services/gateway/internal/clients"
 
+	cmtpb "github.com/mariapetrova3009/insta-backend/proto/comments"
 	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
 	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
 	feedpb "github.com/mariapetrova3009/insta-backend/proto/feed"
 	idpb "github.com/mariapetrova3009/insta-backend/proto/identity"
+	socialpb "github.com/mariapetrova3009/insta-backend/proto/social"
 )
 
 func Healthz() http.HandlerFunc {
@@ -37,7 +39,7 @@ func Register(cl *clients.Clients) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var in req
 		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-			httpError(w, http.StatusBadRequest, "bad json")
+			httpError(w, r, http.StatusBadRequest, "bad json")
 			return
 		}
 
@@ -48,7 +50,7 @@ func Register(cl *clients.Clients) http.HandlerFunc {
 			Bio:      in.Bio,
 		})
 		if err != nil {
-			httpError(w, http.StatusBadGateway, err.Error())
+			grpcError(w, r, err)
 			return
 		}
 		respondJSON(w, http.StatusOK, res)
@@ -65,7 +67,7 @@ func Login(cl *clients.Clients) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var in req
 		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-			httpError(w, http.StatusBadRequest, "bad json")
+			httpError(w, r, http.StatusBadRequest, "bad json")
 			return
 		}
 		res, err := cl.Identity.Login(r.Context(), &idpb.LoginRequest{
@@ -73,7 +75,71 @@ func Login(cl *clients.Clients) http.HandlerFunc {
 			Password:        in.Password,
 		})
 		if err != nil {
-			httpError(w, http.StatusBadGateway, err.Error())
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+func Refresh(cl *clients.Clients) http.HandlerFunc {
+	type req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in req
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			httpError(w, r, http.StatusBadRequest, "bad json")
+			return
+		}
+		res, err := cl.Identity.Refresh(r.Context(), &idpb.RefreshRequest{
+			RefreshToken: in.RefreshToken,
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+func Logout(cl *clients.Clients) http.HandlerFunc {
+	type req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		// тело необязательно: без refresh_token отзываем только access-токен
+		var in req
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				httpError(w, r, http.StatusBadRequest, "bad json")
+				return
+			}
+		}
+
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Identity.Logout(ctx, &idpb.LogoutRequest{
+			RefreshToken: in.RefreshToken,
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+func LogoutAll(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Identity.LogoutAll(ctx, &cmpb.Empty{})
+		if err != nil {
+			grpcError(w, r, err)
+			return
 		}
 		respondJSON(w, http.StatusOK, res)
 	}
@@ -86,67 +152,178 @@ func Me(cl *clients.Clients) http.HandlerFunc {
 		ctx := gatewayauth.Outgoing(r.Context(), md)
 		res, err := cl.Identity.GetProfile(ctx, &idpb.GetProfileRequest{})
 		if err != nil {
-			httpError(w, http.StatusBadGateway, err.Error())
+			grpcError(w, r, err)
 			return
 		}
 		respondJSON(w, http.StatusOK, res)
 	}
 }
 
-// --------------------------------- POSTS -------------------------------------
-func CreatePost(cl *clients.Clients) http.HandlerFunc {
+func UpdateMe(cl *clients.Clients) http.HandlerFunc {
+	// указатели: отсутствующее в JSON поле не трогаем
+	type req struct {
+		Username    *string `json:"username"`
+		Bio         *string `json:"bio"`
+		DisplayName *string `json:"display_name"`
+	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		// 0) multipart
-		if err := r.ParseMultipartForm(32 << 20); err != nil {
-			httpError(w, http.StatusBadRequest, "multipart form required")
+		var in req
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			httpError(w, r, http.StatusBadRequest, "bad json")
+			return
+		}
+
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Identity.UpdateProfile(ctx, &idpb.UpdateProfileRequest{
+			Username:    in.Username,
+			Bio:         in.Bio,
+			DisplayName: in.DisplayName,
+		})
+		if err != nil {
+			grpcError(w, r, err)
 			return
 		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
 
-		// 1) файл
-		file, header, err := r.FormFile("file")
+func SetAvatar(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 0) multipart читаем потоком, файл целиком в память не кладём
+		mr, err := r.MultipartReader()
 		if err != nil {
-			httpError(w, http.StatusBadRequest, "file is required")
+			httpError(w, r, http.StatusBadRequest, "multipart form required")
+			return
+		}
+
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+
+		// 1) ищем part "file" и сразу стримим его в content
+		var up *contentpb.UploadMediaResponse
+		for up == nil {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				httpError(w, r, http.StatusBadRequest, "bad multipart body")
+				return
+			}
+			if part.FormName() != "file" {
+				part.Close()
+				continue
+			}
+
+			data, mime, err := filePart(part)
+			if err != nil {
+				uploadError(w, r, err)
+				return
+			}
+			if !strings.HasPrefix(mime, "image/") {
+				httpError(w, r, http.StatusBadRequest, "avatar must be an image")
+				return
+			}
+			up, err = streamUpload(ctx, cl, part.FileName(), mime, data)
+			if err != nil {
+				uploadError(w, r, err)
+				return
+			}
+		}
+		if up == nil {
+			httpError(w, r, http.StatusBadRequest, "file is required")
 			return
 		}
-		defer file.Close()
 
-		data, err := readAll(file)
+		// 2) привязываем к профилю
+		res, err := cl.Identity.SetAvatar(ctx, &idpb.SetAvatarRequest{
+			MediaPath: up.MediaPath,
+		})
 		if err != nil {
-			httpError(w, http.StatusBadRequest, "read file error")
+			grpcError(w, r, err)
 			return
 		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+// --------------------------------- POSTS -------------------------------------
 
-		// 2) caption + mime
-		caption := r.FormValue("caption")
+// caption длиннее этого не принимаем, чтобы не читать в память что угодно
+const maxCaptionBytes = 8 << 10
 
-		mime := header.Header.Get("Content-Type")
-		if mime == "" {
-			mime = http.DetectContentType(data)
+func CreatePost(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 0) multipart читаем потоком, файл целиком в память не кладём
+		mr, err := r.MultipartReader()
+		if err != nil {
+			httpError(w, r, http.StatusBadRequest, "multipart form required")
+			return
 		}
 
-		// 3) gRPC metadata (Authorization + user-id)
+		// 1) gRPC metadata (Authorization + user-id)
 		md := gatewayauth.MetadataFromHTTP(r)
 		ctx := gatewayauth.Outgoing(r.Context(), md)
 
-		// 4) upload media
-		res, err := cl.Content.UploadMedia(ctx, &contentpb.UploadMediaRequest{
-			Data: data,
-			Mime: mime,
-			Name: header.Filename,
-		})
-		if err != nil {
-			httpError(w, http.StatusBadGateway, err.Error())
-			return // ← обязательно!
+		// 2) file стримим в content сразу, caption может идти до или после него
+		var (
+			caption string
+			res     *contentpb.UploadMediaResponse
+		)
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				httpError(w, r, http.StatusBadRequest, "bad multipart body")
+				return
+			}
+
+			switch part.FormName() {
+			case "caption":
+				b, err := io.ReadAll(io.LimitReader(part, maxCaptionBytes+1))
+				if err != nil {
+					httpError(w, r, http.StatusBadRequest, "read caption error")
+					return
+				}
+				if len(b) > maxCaptionBytes {
+					httpError(w, r, http.StatusBadRequest, "caption is too long")
+					return
+				}
+				caption = string(b)
+			case "file":
+				if res != nil {
+					httpError(w, r, http.StatusBadRequest, "only one file is allowed")
+					return
+				}
+				data, mime, err := filePart(part)
+				if err != nil {
+					uploadError(w, r, err)
+					return
+				}
+				res, err = streamUpload(ctx, cl, part.FileName(), mime, data)
+				if err != nil {
+					uploadError(w, r, err)
+					return
+				}
+			}
+			part.Close()
+		}
+		if res == nil {
+			httpError(w, r, http.StatusBadRequest, "file is required")
+			return
 		}
 
-		// 5) create post
+		// 3) create post
 		cp, err := cl.Content.CreatePost(ctx, &contentpb.CreatePostRequest{
 			Caption:   caption,
 			MediaPath: res.MediaPath,
 			Mime:      res.Mime,
 		})
 		if err != nil {
-			httpError(w, http.StatusBadGateway, err.Error())
+			grpcError(w, r, err)
 			return
 		}
 
@@ -154,35 +331,153 @@ func CreatePost(cl *clients.Clients) http.HandlerFunc {
 	}
 }
 
-// FEED
+func GetPost(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Content.GetPost(ctx, &contentpb.GetPostRequest{
+			PostId: chi.URLParam(r, "id"),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
 
-func GetFeed(cl *clients.Clients) http.HandlerFunc {
+// DeletePost — удалить свой пост
+func DeletePost(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Content.DeletePost(ctx, &contentpb.DeletePostRequest{
+			PostId: chi.URLParam(r, "id"),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+// --------------------------------- SOCIAL ------------------------------------
+
+func Follow(cl *clients.Clients) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var page *cmpb.PageRequest
-		limitStr := r.URL.Query().Get("limit")
-		cursor := r.URL.Query().Get("cursor")
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Social.Follow(ctx, &socialpb.FollowRequest{
+			FolloweeId: chi.URLParam(r, "id"),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
 
-		page = &cmpb.PageRequest{}
-		limitStr = r.URL.Query().Get("limit")
-		cursor = r.URL.Query().Get("cursor")
+func Unfollow(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Social.Unfollow(ctx, &socialpb.FollowRequest{
+			FolloweeId: chi.URLParam(r, "id"),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
 
-		if limitStr == "" || cursor == "" {
-			page = &cmpb.PageRequest{}
+func Like(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Likes.Like(ctx, &socialpb.LikeRequest{
+			PostId: chi.URLParam(r, "id"),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
 		}
-		if limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil {
-				page.Limit = uint32(l)
-			}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+func Unlike(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Likes.Unlike(ctx, &socialpb.LikeRequest{
+			PostId: chi.URLParam(r, "id"),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+// -------------------------------- COMMENTS -----------------------------------
+
+func CreateComment(cl *clients.Clients) http.HandlerFunc {
+	type req struct {
+		Text string `json:"text"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in req
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			httpError(w, r, http.StatusBadRequest, "bad json")
+			return
+		}
+
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+		res, err := cl.Comments.CreateComment(ctx, &cmtpb.CreateCommentRequest{
+			PostId: chi.URLParam(r, "id"),
+			Text:   in.Text,
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
 		}
-		if cursor != "" {
-			page.Cursor = &cmpb.Cursor{Token: cursor}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+func ListComments(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cl.Comments.ListComments(r.Context(), &cmtpb.ListCommentsRequest{
+			PostId: chi.URLParam(r, "id"),
+			Page:   pageFromQuery(r),
+		})
+		if err != nil {
+			grpcError(w, r, err)
+			return
 		}
+		respondJSON(w, http.StatusOK, res)
+	}
+}
+
+// FEED
 
-		res, err := cl.Feed.GetFeed(r.Context(), &feedpb.GetFeedRequest{
-			Page: page,
+func GetFeed(cl *clients.Clients) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Authorization + user-id -> gRPC metadata, feed сам определит владельца ленты
+		md := gatewayauth.MetadataFromHTTP(r)
+		ctx := gatewayauth.Outgoing(r.Context(), md)
+
+		res, err := cl.Feed.GetFeed(ctx, &feedpb.GetFeedRequest{
+			Page: pageFromQuery(r),
 		})
 		if err != nil {
-			httpError(w, http.StatusBadRequest, err.Error())
+			grpcError(w, r, err)
 			return
 		}
 		respondJSON(w, http.StatusOK, res)
@@ -191,11 +486,14 @@ func GetFeed(cl *clients.Clients) http.HandlerFunc {
 
 // ------------------------------ small helpers --------------------------------
 
-// readAll вынесен сюда, чтобы не тянуть лишние зависимости в responses.go
-func readAll(f multipart.File) ([]byte, error) {
-	b, err := io.ReadAll(f)
-	if err != nil {
-		return nil, fmt.Errorf("readAll: %w", err)
+// pageFromQuery собирает PageRequest из ?limit=&cursor=
+func pageFromQuery(r *http.Request) *cmpb.PageRequest {
+	page := &cmpb.PageRequest{}
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		page.Limit = uint32(l)
+	}
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		page.Cursor = &cmpb.Cursor{Token: cursor}
 	}
-	return b, nil
+	return page
 }
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// MediaProxy проксирует GET /media/* в HTTP-сервер content как есть:
+// токен проверяет content, Range/ETag/Cache-Control проходят насквозь
+func MediaProxy(log *slog.Logger, target *url.URL) http.Handler {
+	p := httputil.NewSingleHostReverseProxy(target)
+	p.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		log.Error("media proxy failed", "path", r.URL.Path, "err", err)
+		httpError(w, r, http.StatusBadGateway, "media unavailable")
+	}
+	return p
+}
@@ -3,13 +3,128 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// errorBody — единый формат ошибки: {"error": {...}}
+type errorBody struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	RequestID string            `json:"request_id,omitempty"`
+	Details   []json.RawMessage `json:"details,omitempty"`
+}
+
 func respondJSON(w http.ResponseWriter, code int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	_ = json.NewEncoder(w).Encode(v)
 }
-func httpError(w http.ResponseWriter, code int, msg string) {
-	respondJSON(w, code, map[string]any{"error": msg})
+
+// httpError — ошибка, найденная в самом gateway (bad json, нет файла и т.п.)
+func httpError(w http.ResponseWriter, r *http.Request, code int, msg string) {
+	respondJSON(w, code, map[string]any{"error": errorBody{
+		Code:      httpCodeName(code),
+		Message:   msg,
+		RequestID: middleware.GetReqID(r.Context()),
+	}})
+}
+
+// grpcError переводит ошибку gRPC-вызова в HTTP-ответ:
+// статус по status.Code, message и details — из status
+func grpcError(w http.ResponseWriter, r *http.Request, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		// не gRPC-ошибка — наружу текст не отдаём
+		st = status.New(codes.Internal, "internal error")
+	}
+
+	body := errorBody{
+		Code:      codeName(st.Code()),
+		Message:   st.Message(),
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+	for _, d := range st.Proto().GetDetails() {
+		b, err := protojson.Marshal(d)
+		if err != nil {
+			// тип details не зарегистрирован — отдаём хотя бы его имя
+			b, _ = json.Marshal(map[string]string{"@type": d.GetTypeUrl()})
+		}
+		body.Details = append(body.Details, b)
+	}
+
+	respondJSON(w, httpStatusFromCode(st.Code()), map[string]any{"error": body})
+}
+
+// httpStatusFromCode — как в grpc-gateway
+func httpStatusFromCode(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default: // Unknown, Internal, DataLoss
+		return http.StatusInternalServerError
+	}
+}
+
+// httpCodeName — обратное соответствие для ошибок самого gateway
+func httpCodeName(code int) string {
+	switch code {
+	case http.StatusBadRequest:
+		return codeName(codes.InvalidArgument)
+	case http.StatusUnauthorized:
+		return codeName(codes.Unauthenticated)
+	case http.StatusForbidden:
+		return codeName(codes.PermissionDenied)
+	case http.StatusNotFound:
+		return codeName(codes.NotFound)
+	case http.StatusConflict:
+		return codeName(codes.AlreadyExists)
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codeName(codes.ResourceExhausted)
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codeName(codes.Unavailable)
+	case http.StatusGatewayTimeout:
+		return codeName(codes.DeadlineExceeded)
+	default:
+		return codeName(codes.Internal)
+	}
+}
+
+// codeName: InvalidArgument -> INVALID_ARGUMENT
+func codeName(c codes.Code) string {
+	var b strings.Builder
+	prev := rune(0)
+	for _, r := range c.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	return b.String()
 }
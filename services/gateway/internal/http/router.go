@@ -2,32 +2,78 @@ package http
 
 import (
 	"log/slog"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/revoke"
 	"github.com/mariapetrova3009/insta-backend/services/gateway/internal/auth"
 	"github.com/mariapetrova3009/insta-backend/services/gateway/internal/clients"
 )
 
-func NewRouter(log *slog.Logger, cfg *cfgpkg.Config, cl *clients.Clients) *chi.Mux {
+// uploadTimeout — на POST /posts и PUT /me/avatar вместо общих 15s
+const uploadTimeout = 10 * time.Minute
+
+func NewRouter(log *slog.Logger, cfg *cfgpkg.Config, cl *clients.Clients, revoked revoke.Store) *chi.Mux {
 	r := chi.NewRouter()
-	r.Use(middleware.RequestID, middleware.RealIP, middleware.Recoverer, middleware.Timeout(15*time.Second))
+	r.Use(middleware.RequestID, middleware.RealIP, middleware.Recoverer)
 
-	// handlers.go в том же пакете, поэтому просто вызываем функции без префикса
-	r.Get("/healthz", Healthz())
+	// медиа: без таймаута API (большие файлы, Range), доступ — по токену в ссылке
+	if cfg.Content.HTTPEndpoint != "" {
+		target, err := url.Parse(cfg.Content.HTTPEndpoint)
+		if err != nil {
+			log.Error("bad content.http_endpoint, /media disabled", "err", err)
+		} else {
+			media := MediaProxy(log, target)
+			r.Method(http.MethodGet, "/media/*", media)
+			r.Method(http.MethodHead, "/media/*", media)
+		}
+	} else {
+		log.Warn("content.http_endpoint is empty, /media disabled")
+	}
 
-	// auth
-	r.Post("/auth/register", Register(cl))
-	r.Post("/auth/login", Login(cl))
+	requireJWT := auth.JWTMiddleware([]byte(cfg.JWT.Secret), revoked, cfg.Revoke.FailOpen, httpError)
 
-	r.With(auth.JWTMiddleware([]byte(cfg.JWT.Secret))).Group(func(pr chi.Router) {
-		pr.Get("/me", Me(cl))
-		pr.Post("/posts", CreatePost(cl)) // multipart: file + caption
+	// загрузки: файл до storage.max_upload_size стримится в content, 15s на медленной сети мало
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(uploadTimeout), requireJWT)
+		r.Put("/me/avatar", SetAvatar(cl)) // multipart: file
+		r.Post("/posts", CreatePost(cl))   // multipart: file + caption
 	})
 
-	r.Get("/feed", GetFeed(cl))
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(15 * time.Second))
+
+		// handlers.go в том же пакете, поэтому просто вызываем функции без префикса
+		r.Get("/healthz", Healthz())
+
+		// auth
+		r.Post("/auth/register", Register(cl))
+		r.Post("/auth/login", Login(cl))
+		r.Post("/auth/refresh", Refresh(cl))
+
+		r.With(requireJWT).Group(func(pr chi.Router) {
+			pr.Get("/me", Me(cl))
+			pr.Patch("/me", UpdateMe(cl))
+			pr.Post("/auth/logout", Logout(cl))
+			pr.Post("/auth/logout-all", LogoutAll(cl))
+			pr.Get("/feed", GetFeed(cl))
+			pr.Get("/posts/{id}", GetPost(cl))
+			pr.Delete("/posts/{id}", DeletePost(cl))
+			pr.Post("/posts/{id}/like", Like(cl))
+			pr.Delete("/posts/{id}/like", Unlike(cl))
+			pr.Post("/posts/{id}/comments", CreateComment(cl))
+
+			// social graph
+			pr.Post("/users/{id}/follow", Follow(cl))
+			pr.Delete("/users/{id}/follow", Unfollow(cl))
+		})
+
+		r.Get("/posts/{id}/comments", ListComments(cl))
+	})
 	return r
 }
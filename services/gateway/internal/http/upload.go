@@ -0,0 +1,93 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	contentpb "github.com/mariapetrova3009/insta-backend/proto/content"
+	"github.com/mariapetrova3009/insta-backend/services/gateway/internal/clients"
+)
+
+// размер одного chunk'а UploadMediaStream — сильно меньше 4 МБ лимита сообщения gRPC
+const uploadChunkSize = 64 << 10
+
+// errReadFile — файл оборвался на стороне клиента, content тут ни при чём
+var errReadFile = errors.New("read file error")
+
+// filePart — файл из multipart без чтения целиком: mime из заголовка part'а,
+// иначе по первым 512 байтам
+func filePart(p *multipart.Part) (io.Reader, string, error) {
+	br := bufio.NewReaderSize(p, 512)
+	mime := p.Header.Get("Content-Type")
+	if mime == "" {
+		head, err := br.Peek(512)
+		if err != nil && err != io.EOF {
+			return nil, "", fmt.Errorf("%w: %v", errReadFile, err)
+		}
+		mime = http.DetectContentType(head)
+	}
+	return br, mime, nil
+}
+
+// streamUpload отправляет файл в content.UploadMediaStream кусками по мере чтения
+func streamUpload(ctx context.Context, cl *clients.Clients, name, mime string, r io.Reader) (*contentpb.UploadMediaResponse, error) {
+	// при ошибке чтения отменяем стрим, чтобы content не сохранил обрезанный файл
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := cl.Content.UploadMediaStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&contentpb.UploadMediaChunk{
+		Payload: &contentpb.UploadMediaChunk_Header{
+			Header: &contentpb.UploadMediaHeader{Name: name, Mime: mime},
+		},
+	})
+	if err != nil {
+		return nil, closeUpload(stream, err)
+	}
+
+	for {
+		// новый буфер на каждый chunk: gRPC может сериализовать сообщение позже
+		buf := make([]byte, uploadChunkSize)
+		n, rerr := io.ReadFull(r, buf)
+		if n > 0 {
+			err := stream.Send(&contentpb.UploadMediaChunk{
+				Payload: &contentpb.UploadMediaChunk_Chunk{Chunk: buf[:n]},
+			})
+			if err != nil {
+				return nil, closeUpload(stream, err)
+			}
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rerr != nil {
+			return nil, fmt.Errorf("%w: %v", errReadFile, rerr)
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// closeUpload: Send отдаёт io.EOF, когда сервер уже закрыл стрим — настоящая ошибка в CloseAndRecv
+func closeUpload(stream contentpb.ContentService_UploadMediaStreamClient, err error) error {
+	if err == io.EOF {
+		_, err = stream.CloseAndRecv()
+	}
+	return err
+}
+
+// uploadError: обрыв со стороны клиента — 400, остальное — статус от content
+func uploadError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errReadFile) {
+		httpError(w, r, http.StatusBadRequest, "read file error")
+		return
+	}
+	grpcError(w, r, err)
+}
@@ -14,6 +14,7 @@ import (
 	_ "github.com/lib/pq"
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
 	logpkg "github.com/mariapetrova3009/insta-backend/pkg/logger"
+	"github.com/mariapetrova3009/insta-backend/pkg/revoke"
 	idpb "github.com/mariapetrova3009/insta-backend/proto/identity"
 	identitysvc "github.com/mariapetrova3009/insta-backend/services/identity/internal/identity"
 	"google.golang.org/grpc"
@@ -62,7 +63,12 @@ func main() {
 	if cfg.Env != "prod" {
 		reflection.Register(grpcSrv)
 	}
-	srv := identitysvc.New(log, cfg, repo)
+	revoked, err := revoke.FromConfig(cfg)
+	if err != nil {
+		log.Error("revocation store", "err", err)
+		return
+	}
+	srv := identitysvc.New(log, cfg, repo, revoked)
 	idpb.RegisterIdentityServiceServer(grpcSrv, srv)
 
 	// run services
@@ -0,0 +1,123 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	idpb "github.com/mariapetrova3009/insta-backend/proto/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxBioLen         = 150
+	maxDisplayNameLen = 64
+)
+
+// латиница, цифры, точка и подчёркивание; без "@", чтобы не путать с email при логине
+var usernameRe = regexp.MustCompile(`^[a-zA-Z0-9._]{3,30}$`)
+
+// валидация заданных полей
+// проверка, что новый username свободен
+// частичный UPDATE в бд
+func (s *Server) UpdateProfile(ctx context.Context, req *idpb.UpdateProfileRequest) (*idpb.GetProfileResponse, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	patch, err := validateProfilePatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Username != nil {
+		u, err := s.repo.GetUserByEmailOrName(ctx, *patch.Username)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "db error")
+		}
+		if u != nil && u.ID != uid {
+			return nil, status.Error(codes.AlreadyExists, "username already taken")
+		}
+	}
+
+	if err := s.repo.UpdateProfile(ctx, uid, patch); err != nil {
+		if errors.Is(err, ErrUsernameTaken) {
+			return nil, status.Error(codes.AlreadyExists, "username already taken")
+		}
+		s.log.Error("update profile failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return s.profile(ctx, uid)
+}
+
+// медиа уже лежит в хранилище content (UploadMedia), здесь только привязка к пользователю;
+// поставить можно только загруженное самим пользователем
+func (s *Server) SetAvatar(ctx context.Context, req *idpb.SetAvatarRequest) (*idpb.GetProfileResponse, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	if req == nil || req.MediaPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "media_path is required")
+	}
+
+	mime, err := s.repo.OwnMediaMime(ctx, uid, req.MediaPath)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if mime == "" { // нет или чужое — не различаем, чтобы не выдавать чужие пути
+		return nil, status.Error(codes.NotFound, "media not found")
+	}
+	if !strings.HasPrefix(mime, "image/") {
+		return nil, status.Error(codes.InvalidArgument, "avatar must be an image")
+	}
+
+	if err := s.repo.SetAvatar(ctx, uid, req.MediaPath); err != nil {
+		s.log.Error("set avatar failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return s.profile(ctx, uid)
+}
+
+func (s *Server) profile(ctx context.Context, uid string) (*idpb.GetProfileResponse, error) {
+	u, err := s.repo.GetUserByID(ctx, uid)
+	if err != nil || u == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &idpb.GetProfileResponse{User: toPBUser(u)}, nil
+}
+
+func validateProfilePatch(req *idpb.UpdateProfileRequest) (ProfilePatch, error) {
+	var p ProfilePatch
+	if req.Username != nil {
+		name := strings.TrimSpace(req.GetUsername())
+		if !usernameRe.MatchString(name) {
+			return p, status.Error(codes.InvalidArgument, "invalid username (3-30 chars: letters, digits, '.', '_')")
+		}
+		p.Username = &name
+	}
+	if req.Bio != nil {
+		bio := strings.TrimSpace(req.GetBio())
+		if utf8.RuneCountInString(bio) > maxBioLen {
+			return p, status.Errorf(codes.InvalidArgument, "bio too long (max %d)", maxBioLen)
+		}
+		p.Bio = &bio
+	}
+	if req.DisplayName != nil {
+		dn := strings.TrimSpace(req.GetDisplayName())
+		if utf8.RuneCountInString(dn) > maxDisplayNameLen {
+			return p, status.Errorf(codes.InvalidArgument, "display_name too long (max %d)", maxDisplayNameLen)
+		}
+		p.DisplayName = &dn
+	}
+	return p, nil
+}
@@ -7,21 +7,33 @@ import (
 	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib" // драйвер pgx/stdlib для database/sql
+	"github.com/lib/pq"
+	"github.com/mariapetrova3009/insta-backend/pkg/mediaref"
 )
 
+var ErrUsernameTaken = errors.New("username already taken")
+
 type Repo struct {
 	DB *sql.DB
 }
 
 // модели для чтения/записи
 type DBUser struct {
-	ID         string
-	Email      string
-	Username   string
-	PassHash   string
-	Bio        string
-	AvatarPath string
-	CreatedAt  time.Time
+	ID          string
+	Email       string
+	Username    string
+	PassHash    string
+	Bio         string
+	AvatarPath  string
+	DisplayName string
+	CreatedAt   time.Time
+}
+
+// поля профиля для частичного обновления: nil — не трогаем
+type ProfilePatch struct {
+	Username    *string
+	Bio         *string
+	DisplayName *string
 }
 
 func (r *Repo) CreateUser(ctx context.Context, u DBUser) error {
@@ -35,13 +47,13 @@ func (r *Repo) CreateUser(ctx context.Context, u DBUser) error {
 
 func (r *Repo) GetUserByEmailOrName(ctx context.Context, emailOrName string) (*DBUser, error) {
 	row := r.DB.QueryRowContext(ctx, `
-		SELECT id, email, username, pass_hash, bio, avatar_path, created_at
+		SELECT id, email, username, pass_hash, bio, avatar_path, display_name, created_at
 		FROM users
 		WHERE email = $1 OR username = $1
 	`, emailOrName)
 
 	var u DBUser
-	if err := row.Scan(&u.ID, &u.Email, &u.Username, &u.PassHash, &u.Bio, &u.AvatarPath, &u.CreatedAt); err != nil {
+	if err := row.Scan(&u.ID, &u.Email, &u.Username, &u.PassHash, &u.Bio, &u.AvatarPath, &u.DisplayName, &u.CreatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -52,11 +64,11 @@ func (r *Repo) GetUserByEmailOrName(ctx context.Context, emailOrName string) (*D
 
 func (r *Repo) GetUserByID(ctx context.Context, id string) (*DBUser, error) {
 	row := r.DB.QueryRowContext(ctx, `
-		SELECT id, email, username, pass_hash, bio, avatar_path, created_at
+		SELECT id, email, username, pass_hash, bio, avatar_path, display_name, created_at
 		FROM users WHERE id = $1
 	`, id)
 	var u DBUser
-	if err := row.Scan(&u.ID, &u.Email, &u.Username, &u.PassHash, &u.Bio, &u.AvatarPath, &u.CreatedAt); err != nil {
+	if err := row.Scan(&u.ID, &u.Email, &u.Username, &u.PassHash, &u.Bio, &u.AvatarPath, &u.DisplayName, &u.CreatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -64,3 +76,143 @@ func (r *Repo) GetUserByID(ctx context.Context, id string) (*DBUser, error) {
 	}
 	return &u, nil
 }
+
+// UpdateProfile обновляет только заданные поля; ErrUsernameTaken — если username занят
+func (r *Repo) UpdateProfile(ctx context.Context, id string, p ProfilePatch) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET
+			username     = COALESCE($2, username),
+			bio          = COALESCE($3, bio),
+			display_name = COALESCE($4, display_name)
+		WHERE id = $1
+	`, id, p.Username, p.Bio, p.DisplayName)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+		return ErrUsernameTaken
+	}
+	return err
+}
+
+// SetAvatar меняет аватарку и переносит ссылку (media.ref_count) со старого медиа на новое.
+// Старый файл здесь не удаляется: медиа без ссылок убирает content (см. pkg/mediaref).
+func (r *Repo) SetAvatar(ctx context.Context, id, path string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var old sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT avatar_path FROM users WHERE id = $1 FOR UPDATE`, id).Scan(&old)
+	if err != nil {
+		return err
+	}
+	if old.String == path {
+		return tx.Commit()
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET avatar_path = $2 WHERE id = $1`, id, path); err != nil {
+		return err
+	}
+	if _, err := mediaref.AcquirePath(ctx, tx, path); err != nil {
+		return err
+	}
+	if old.String != "" {
+		if err := mediaref.ReleasePath(ctx, tx, old.String); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// OwnMediaMime — mime медиа, которое загружал userID; "" если такого нет или оно чужое
+func (r *Repo) OwnMediaMime(ctx context.Context, userID, path string) (string, error) {
+	return mediaref.UploadedBy(ctx, r.DB, path, userID)
+}
+
+type DBSession struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	RotatedAt sql.NullTime
+	RevokedAt sql.NullTime
+}
+
+func (r *Repo) CreateSession(ctx context.Context, s DBSession) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO sessions (id, user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, s.ID, s.UserID, s.FamilyID, s.TokenHash, s.ExpiresAt)
+	return err
+}
+
+func (r *Repo) GetSessionByHash(ctx context.Context, hash string) (*DBSession, error) {
+	row := r.DB.QueryRowContext(ctx, `
+		SELECT id, user_id, family_id, token_hash, expires_at, created_at, rotated_at, revoked_at
+		FROM sessions WHERE token_hash = $1
+	`, hash)
+	var s DBSession
+	if err := row.Scan(&s.ID, &s.UserID, &s.FamilyID, &s.TokenHash, &s.ExpiresAt, &s.CreatedAt, &s.RotatedAt, &s.RevokedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// RotateSession помечает старую сессию использованной и создаёт следующую в той же семье.
+// ok=false — старую уже кто-то ротировал (гонка или повторное использование токена).
+func (r *Repo) RotateSession(ctx context.Context, oldID string, next DBSession) (bool, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE sessions SET rotated_at = now()
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+	`, oldID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO sessions (id, user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, next.ID, next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// RevokeFamily отзывает всю цепочку refresh-токенов одного логина
+func (r *Repo) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`, familyID)
+	return err
+}
+
+// RevokeUserSessions отзывает все refresh-сессии пользователя (logout everywhere)
+func (r *Repo) RevokeUserSessions(ctx context.Context, userID string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID)
+	return err
+}
@@ -5,10 +5,11 @@ import (
 	"errors"
 	"log/slog"
 	"net/mail"
-	"strings"
 	"time"
 
+	authpkg "github.com/mariapetrova3009/insta-backend/pkg/auth"
 	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/revoke"
 	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
 	idpb "github.com/mariapetrova3009/insta-backend/proto/identity"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -17,7 +18,6 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -26,18 +26,28 @@ import (
 // gRPC server realisation
 type Server struct {
 	idpb.UnimplementedIdentityServiceServer
-	log       *slog.Logger
-	jwtSecret []byte
-	accessTTL time.Duration
-	repo      *Repo
+	log        *slog.Logger
+	jwtSecret  []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	repo       *Repo
+	revoked    revoke.Store
+	failOpen   bool // revoke.fail_open: пускать, если хранилище отзыва недоступно
 }
 
-func New(log *slog.Logger, cfg *cfgpkg.Config, repo *Repo) *Server {
+func New(log *slog.Logger, cfg *cfgpkg.Config, repo *Repo, revoked revoke.Store) *Server {
+	refreshTTL := cfg.JWT.RefreshTTL
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTTL
+	}
 	return &Server{
-		log:       log,
-		jwtSecret: []byte(cfg.JWT.Secret),
-		accessTTL: cfg.JWT.TTL,
-		repo:      repo,
+		log:        log,
+		jwtSecret:  []byte(cfg.JWT.Secret),
+		accessTTL:  cfg.JWT.TTL,
+		refreshTTL: refreshTTL,
+		repo:       repo,
+		revoked:    revoked,
+		failOpen:   cfg.Revoke.FailOpen,
 	}
 }
 
@@ -77,15 +87,18 @@ func (s *Server) Register(ctx context.Context, req *idpb.RegisterRequest) (*idpb
 	if err != nil {
 		return nil, status.Error(codes.Internal, "token error")
 	}
+	refresh, err := s.startSession(ctx, id)
+	if err != nil {
+		s.log.Error("create session failed", "err", err)
+		return nil, status.Error(codes.Internal, "token error")
+	}
 
 	// читаем пользователя, чтобы отдать created_at
 	dbu, _ := s.repo.GetUserByID(ctx, id)
 	return &idpb.AuthResponse{
-		AccessToken: access,
-		User: &cmpb.User{
-			Id: dbu.ID, Email: dbu.Email, Username: dbu.Username, Bio: dbu.Bio,
-			CreatedAt: timestamppb.New(dbu.CreatedAt),
-		},
+		AccessToken:  access,
+		RefreshToken: refresh,
+		User:         toPBUser(dbu),
 	}, nil
 }
 
@@ -124,13 +137,16 @@ func (s *Server) Login(ctx context.Context, req *idpb.LoginRequest) (*idpb.AuthR
 	if err != nil {
 		return nil, status.Error(codes.Internal, "token error")
 	}
+	refresh, err := s.startSession(ctx, u.ID)
+	if err != nil {
+		s.log.Error("create session failed", "err", err)
+		return nil, status.Error(codes.Internal, "token error")
+	}
 
 	return &idpb.AuthResponse{
-		AccessToken: access,
-		User: &cmpb.User{
-			Id: u.ID, Email: u.Email, Username: u.Username, Bio: u.Bio,
-			CreatedAt: timestamppb.New(u.CreatedAt),
-		},
+		AccessToken:  access,
+		RefreshToken: refresh,
+		User:         toPBUser(u),
 	}, nil
 }
 
@@ -146,6 +162,7 @@ func validateLogin(req *idpb.LoginRequest) error {
 func (s *Server) issueAccessToken(userID string) (string, error) {
 	now := time.Now()
 	claims := jwt.RegisteredClaims{
+		ID:        uuid.NewString(), // jti — для точечного отзыва
 		Subject:   userID,
 		ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTTL)),
 		IssuedAt:  jwt.NewNumericDate(now),
@@ -164,12 +181,13 @@ func toPBUser(u *DBUser) *cmpb.User {
 		return nil
 	}
 	return &cmpb.User{
-		Id:         u.ID,
-		Email:      u.Email,
-		Username:   u.Username,
-		Bio:        u.Bio,
-		AvatarPath: u.AvatarPath,
-		CreatedAt:  timestamppb.New(u.CreatedAt),
+		Id:          u.ID,
+		Email:       u.Email,
+		Username:    u.Username,
+		Bio:         u.Bio,
+		AvatarPath:  u.AvatarPath,
+		DisplayName: u.DisplayName,
+		CreatedAt:   timestamppb.New(u.CreatedAt),
 	}
 }
 
@@ -189,35 +207,32 @@ func (s *Server) GetProfile(ctx context.Context, req *idpb.GetProfileRequest) (*
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
-	return &idpb.GetProfileResponse{User: &cmpb.User{
-		Id: u.ID, Email: u.Email, Username: u.Username, Bio: u.Bio,
-		CreatedAt: timestamppb.New(u.CreatedAt),
-	}}, nil
+	return &idpb.GetProfileResponse{User: toPBUser(u)}, nil
 }
 
 func (s *Server) userIDFromAuth(ctx context.Context) (string, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return "", errors.New("no metadata")
-	}
-	vals := md.Get("authorization")
-	if len(vals) == 0 {
-		return "", errors.New("no authorization")
-	}
-	token := vals[0]
-	if strings.HasPrefix(strings.ToLower(token), "bearer ") {
-		token = token[7:]
+	claims, err := s.claimsFromAuth(ctx)
+	if err != nil {
+		return "", err
 	}
+	return claims.Subject, nil
+}
 
-	claims := &jwt.RegisteredClaims{}
-	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
-		if t.Method != jwt.SigningMethodHS256 {
-			return nil, errors.New("unexpected signing method")
+// claimsFromAuth: подпись + проверка по списку отзыва
+func (s *Server) claimsFromAuth(ctx context.Context) (*jwt.RegisteredClaims, error) {
+	claims, err := authpkg.ClaimsFromIncoming(ctx, s.jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := revoke.IsClaimsRevoked(ctx, s.revoked, claims)
+	if err != nil {
+		s.log.Warn("revocation check failed", "err", err)
+		if !s.failOpen {
+			return nil, errors.New("revocation check unavailable")
 		}
-		return s.jwtSecret, nil
-	})
-	if err != nil || claims.Subject == "" {
-		return "", errors.New("invalid token")
 	}
-	return claims.Subject, nil
+	if revoked {
+		return nil, errors.New("token revoked")
+	}
+	return claims, nil
 }
@@ -0,0 +1,160 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
+	idpb "github.com/mariapetrova3009/insta-backend/proto/identity"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// если jwt.refresh_ttl не задан
+const defaultRefreshTTL = 30 * 24 * time.Hour
+
+// проверка refresh-токена по хэшу
+// повторное использование уже ротированного токена -> отзываем всю семью
+// ротация: старый помечаем, выдаём новую пару access/refresh
+func (s *Server) Refresh(ctx context.Context, req *idpb.RefreshRequest) (*idpb.AuthResponse, error) {
+	if req == nil || req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	sess, err := s.repo.GetSessionByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		s.log.Error("session lookup failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if sess == nil || sess.RevokedAt.Valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	if sess.RotatedAt.Valid {
+		s.revokeFamily(ctx, sess)
+		return nil, status.Error(codes.Unauthenticated, "refresh token reuse detected")
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "refresh token expired")
+	}
+
+	refresh, next := s.newSession(sess.UserID, sess.FamilyID)
+	ok, err := s.repo.RotateSession(ctx, sess.ID, next)
+	if err != nil {
+		s.log.Error("rotate session failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if !ok {
+		// тот же токен параллельно ротировали — считаем это reuse
+		s.revokeFamily(ctx, sess)
+		return nil, status.Error(codes.Unauthenticated, "refresh token reuse detected")
+	}
+
+	access, err := s.issueAccessToken(sess.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "token error")
+	}
+
+	u, err := s.repo.GetUserByID(ctx, sess.UserID)
+	if err != nil || u == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &idpb.AuthResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		User:         toPBUser(u),
+	}, nil
+}
+
+// отзываем текущий access-токен (jti до его exp)
+// и, если передан refresh-токен этого пользователя, всю его семью
+func (s *Server) Logout(ctx context.Context, req *idpb.LogoutRequest) (*cmpb.Empty, error) {
+	claims, err := s.claimsFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+
+	exp := time.Now().Add(s.accessTTL)
+	if claims.ExpiresAt != nil {
+		exp = claims.ExpiresAt.Time
+	}
+	if err := s.revoked.RevokeToken(ctx, claims.ID, exp); err != nil {
+		s.log.Error("revoke token failed", "err", err)
+		return nil, status.Error(codes.Internal, "revoke error")
+	}
+
+	if req != nil && req.RefreshToken != "" {
+		sess, err := s.repo.GetSessionByHash(ctx, hashToken(req.RefreshToken))
+		if err != nil {
+			s.log.Error("session lookup failed", "err", err)
+			return nil, status.Error(codes.Internal, "db error")
+		}
+		if sess != nil && sess.UserID == claims.Subject {
+			if err := s.repo.RevokeFamily(ctx, sess.FamilyID); err != nil {
+				s.log.Error("revoke family failed", "err", err)
+				return nil, status.Error(codes.Internal, "db error")
+			}
+		}
+	}
+	return &cmpb.Empty{}, nil
+}
+
+// "выйти везде": все refresh-сессии + все access-токены, выпущенные до этого момента
+func (s *Server) LogoutAll(ctx context.Context, _ *cmpb.Empty) (*cmpb.Empty, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+
+	if err := s.repo.RevokeUserSessions(ctx, uid); err != nil {
+		s.log.Error("revoke sessions failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	// iat в JWT с точностью до секунды: отсекаем всё, что выпущено в эту секунду и раньше
+	if err := s.revoked.RevokeUser(ctx, uid, time.Now().Truncate(time.Second)); err != nil {
+		s.log.Error("revoke user tokens failed", "err", err)
+		return nil, status.Error(codes.Internal, "revoke error")
+	}
+	return &cmpb.Empty{}, nil
+}
+
+// startSession открывает новую семью refresh-токенов (Register/Login)
+func (s *Server) startSession(ctx context.Context, userID string) (string, error) {
+	token, sess := s.newSession(userID, uuid.NewString())
+	if err := s.repo.CreateSession(ctx, sess); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// newSession генерирует непрозрачный токен; в БД уходит только его хэш
+func (s *Server) newSession(userID, familyID string) (string, DBSession) {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, DBSession{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	}
+}
+
+func (s *Server) revokeFamily(ctx context.Context, sess *DBSession) {
+	s.log.Warn("refresh token reuse, revoking session family", "user_id", sess.UserID, "family_id", sess.FamilyID)
+	if err := s.repo.RevokeFamily(ctx, sess.FamilyID); err != nil {
+		s.log.Error("revoke family failed", "err", err)
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Refresh-сессии: каждый refresh-токен — одна строка, хранится только sha256-хэш.
+-- family_id объединяет цепочку ротаций одного логина (для отзыва при reuse).
+CREATE TABLE IF NOT EXISTS sessions (
+  id          uuid PRIMARY KEY,
+  user_id     uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  family_id   uuid NOT NULL,
+  token_hash  text NOT NULL UNIQUE,
+  expires_at  timestamptz NOT NULL,
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  rotated_at  timestamptz,
+  revoked_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_family ON sessions (family_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sessions_user;
+DROP INDEX IF EXISTS idx_sessions_family;
+DROP TABLE IF EXISTS sessions;
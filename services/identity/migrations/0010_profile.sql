@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS display_name text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,82 @@
+package social
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mariapetrova3009/insta-backend/pkg/events"
+	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
+	evpb "github.com/mariapetrova3009/insta-backend/proto/events"
+	socialpb "github.com/mariapetrova3009/insta-backend/proto/social"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// проверка токена и поста
+// лайк + likes_count в одной транзакции
+// событие LikeCreated (только для нового лайка)
+func (s *Server) Like(ctx context.Context, req *socialpb.LikeRequest) (*cmpb.Empty, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	postID, err := validatePostID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.PostExists(ctx, postID)
+	if err != nil {
+		s.log.Error("post lookup failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+
+	created, err := s.repo.Like(ctx, uid, postID)
+	if err != nil {
+		s.log.Error("like failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if created {
+		if err := events.Produce(s.prod, s.topicLikeCreated, postID, &evpb.LikeCreated{
+			UserId:    uid,
+			PostId:    postID,
+			CreatedAt: timestamppb.New(time.Now().UTC()),
+		}); err != nil {
+			s.log.Error("publish event failed", "err", err)
+		}
+	}
+	return &cmpb.Empty{}, nil
+}
+
+func (s *Server) Unlike(ctx context.Context, req *socialpb.LikeRequest) (*cmpb.Empty, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	postID, err := validatePostID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Unlike(ctx, uid, postID); err != nil {
+		s.log.Error("unlike failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	return &cmpb.Empty{}, nil
+}
+
+func validatePostID(req *socialpb.LikeRequest) (string, error) {
+	if req == nil || req.PostId == "" {
+		return "", status.Error(codes.InvalidArgument, "post_id is required")
+	}
+	id, err := uuid.Parse(req.PostId)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid post_id")
+	}
+	return id.String(), nil
+}
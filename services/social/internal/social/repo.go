@@ -0,0 +1,127 @@
+package social
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Repo struct {
+	DB *sql.DB
+}
+
+func NewRepo(db *sql.DB) *Repo {
+	return &Repo{DB: db}
+}
+
+// UserExists проверяет, что пользователь зарегистрирован (таблица users у identity)
+func (r *Repo) UserExists(ctx context.Context, id string) (bool, error) {
+	var ok bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, id).Scan(&ok)
+	return ok, err
+}
+
+// Follow пишет подписку; created=false, если она уже была (идемпотентно)
+func (r *Repo) Follow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO follows (follower_id, followee_id)
+		VALUES ($1, $2)
+		ON CONFLICT (follower_id, followee_id) DO NOTHING
+	`, followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Unfollow удаляет подписку; отсутствие строки — не ошибка (deleted=false)
+func (r *Repo) Unfollow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2
+	`, followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// PostExists проверяет, что пост существует (таблица posts у content)
+func (r *Repo) PostExists(ctx context.Context, id string) (bool, error) {
+	var ok bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&ok)
+	return ok, err
+}
+
+// Like ставит лайк и увеличивает posts.likes_count в одной транзакции;
+// created=false, если лайк уже был (счётчик не трогаем)
+func (r *Repo) Like(ctx context.Context, userID, postID string) (bool, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO likes (user_id, post_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, post_id) DO NOTHING
+	`, userID, postID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE posts SET likes_count = likes_count + 1 WHERE id = $1
+	`, postID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// Unlike снимает лайк и уменьшает posts.likes_count; повторный вызов ничего не делает
+func (r *Repo) Unlike(ctx context.Context, userID, postID string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM likes WHERE user_id = $1 AND post_id = $2
+	`, userID, postID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE posts SET likes_count = GREATEST(likes_count - 1, 0) WHERE id = $1
+	`, postID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,130 @@
+package social
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/google/uuid"
+	authpkg "github.com/mariapetrova3009/insta-backend/pkg/auth"
+	cfgpkg "github.com/mariapetrova3009/insta-backend/pkg/config"
+	"github.com/mariapetrova3009/insta-backend/pkg/events"
+	cmpb "github.com/mariapetrova3009/insta-backend/proto/common"
+	evpb "github.com/mariapetrova3009/insta-backend/proto/events"
+	socialpb "github.com/mariapetrova3009/insta-backend/proto/social"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gRPC server realisation
+type Server struct {
+	socialpb.UnimplementedSocialGraphServiceServer
+	socialpb.UnimplementedLikeServiceServer
+	log       *slog.Logger
+	jwtSecret []byte
+	repo      *Repo
+
+	prod               *kafka.Producer
+	topicFollowCreated string
+	topicFollowDeleted string
+	topicLikeCreated   string
+}
+
+func New(log *slog.Logger, cfg *cfgpkg.Config, repo *Repo, prod *kafka.Producer) *Server {
+	return &Server{
+		log:                log,
+		jwtSecret:          []byte(cfg.JWT.Secret),
+		repo:               repo,
+		prod:               prod,
+		topicFollowCreated: cfg.Kafka.Topics.FollowCreated,
+		topicFollowDeleted: cfg.Kafka.Topics.FollowDeleted,
+		topicLikeCreated:   cfg.Kafka.Topics.LikeCreated,
+	}
+}
+
+// проверка токена
+// валидация followee (uuid, не сам себя, существует)
+// запись в follows + событие FollowCreated (только для новой подписки)
+func (s *Server) Follow(ctx context.Context, req *socialpb.FollowRequest) (*socialpb.FollowResponse, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	followee, err := s.validateFollowee(uid, req)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.UserExists(ctx, followee)
+	if err != nil {
+		s.log.Error("user lookup failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	created, err := s.repo.Follow(ctx, uid, followee)
+	if err != nil {
+		s.log.Error("follow failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if created {
+		if err := events.Produce(s.prod, s.topicFollowCreated, uid, &evpb.FollowCreated{
+			FollowerId: uid,
+			FolloweeId: followee,
+			CreatedAt:  timestamppb.New(time.Now().UTC()),
+		}); err != nil {
+			s.log.Error("publish event failed", "err", err)
+		}
+	}
+
+	return &socialpb.FollowResponse{FollowerId: uid, FolloweeId: followee}, nil
+}
+
+func (s *Server) Unfollow(ctx context.Context, req *socialpb.FollowRequest) (*cmpb.Empty, error) {
+	uid, err := s.userIDFromAuth(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	followee, err := s.validateFollowee(uid, req)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.repo.Unfollow(ctx, uid, followee)
+	if err != nil {
+		s.log.Error("unfollow failed", "err", err)
+		return nil, status.Error(codes.Internal, "db error")
+	}
+	if deleted {
+		if err := events.Produce(s.prod, s.topicFollowDeleted, uid, &evpb.FollowDeleted{
+			FollowerId: uid,
+			FolloweeId: followee,
+			DeletedAt:  timestamppb.New(time.Now().UTC()),
+		}); err != nil {
+			s.log.Error("publish event failed", "err", err)
+		}
+	}
+	return &cmpb.Empty{}, nil
+}
+
+func (s *Server) validateFollowee(uid string, req *socialpb.FollowRequest) (string, error) {
+	if req == nil || req.FolloweeId == "" {
+		return "", status.Error(codes.InvalidArgument, "followee_id is required")
+	}
+	id, err := uuid.Parse(req.FolloweeId)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid followee_id")
+	}
+	if id.String() == uid {
+		return "", status.Error(codes.InvalidArgument, "cannot follow yourself")
+	}
+	return id.String(), nil
+}
+
+func (s *Server) userIDFromAuth(ctx context.Context) (string, error) {
+	return authpkg.UserIDFromIncoming(ctx, s.jwtSecret)
+}
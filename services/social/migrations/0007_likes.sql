@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- Лайки: один лайк на пару (пользователь, пост)
+CREATE TABLE IF NOT EXISTS likes (
+  user_id    uuid NOT NULL,
+  post_id    uuid NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, post_id)
+);
+-- Лайки поста (удаление поста, список лайкнувших)
+CREATE INDEX IF NOT EXISTS idx_likes_post ON likes (post_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_likes_post;
+DROP TABLE IF EXISTS likes;